@@ -1,15 +1,23 @@
 package goty
 
 import (
+	"encoding"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"golift.io/goty/gotyface"
 )
 
+// ErrUnsupported is the panic when a member cannot be marshalled and the override says to fail.
+var ErrUnsupported = errors.New("type cannot be marshalled")
+
 // Goty is the main struct for the builder.
 // It's used to build typescript interfaces from go structs.
 type Goty struct {
@@ -25,6 +33,24 @@ type Goty struct {
 	// pkgPaths is a list of package paths that we have parsed.
 	// This is so you can parse docs after you parse structs.
 	pkgPaths map[string]struct{}
+	// generics is a map of generic go type names to their generic typescript interfaces.
+	generics map[string]*DataStruct
+	// instances are the instantiated generic types that use a generic interface.
+	instances map[reflect.Type]bool
+	// typeArgs are replaced by type parameters while a generic interface is parsed.
+	typeArgs map[reflect.Type]string
+	// sampled is a list of addressable struct samples that were already parsed.
+	sampled map[sample]bool
+	// warnings are problems we worked around while parsing, like renamed interfaces.
+	warnings []string
+	// view is the view being parsed, and differ are the types that get the view's suffix.
+	view   *View
+	differ map[reflect.Type]bool
+	// viewElems are the elements parsed with views so far, and viewCaches have the types each view parsed.
+	viewElems  []any
+	viewCaches []viewCache
+	// unions are parsed again in each view, because their implementations may be different.
+	unions []*union
 	// output is what we build up as we parse the input struct(s).
 	// We use a slice to preserve the order of the input structs.
 	// Otherwise we could just use the structTypes map.
@@ -54,6 +80,16 @@ type DataStruct struct {
 	// Extends is a list of struct names that this struct extends.
 	// This happens when a struct is anonymously embedded in another struct.
 	Extends []string
+	// Alias is the typescript type this type is an alias for, ie. string.
+	// If this is set there are no members or elements.
+	Alias string
+	// TypeParams is a list of type parameter names if this is a generic interface.
+	TypeParams []string
+	// root and path are set on anonymous structs; they have no type name to find docs with.
+	// root is the named go type they are in. path starts with the root's go type name,
+	// followed by the go field names that lead to the anonymous struct.
+	root reflect.Type
+	path []string
 }
 
 // StructMember is the internal representation of a member of a typescript interface.
@@ -72,8 +108,17 @@ type StructMember struct {
 	Extends []string
 	// Member is the struct field that we are building the typescript interface for.
 	Member reflect.StructField
-	// Optional is true if the member is optional.
+	// Optional is true if the member may be missing, ie. `omitempty`. Adds a question mark.
 	Optional bool
+	// Nullable is true if the member may be null, ie. a nil pointer, slice or map. Adds `| null`.
+	Nullable bool
+	// Readonly is true if the member has the readonly option in a goty tag or field override.
+	Readonly bool
+	// unsupported is true if the marshaller cannot encode the member.
+	unsupported bool
+	// IndexSignature is true if the member holds unknown object members, ie. `[key: string]: any`.
+	// The Name is not used. This only happens with the json v2 inline and unknown options.
+	IndexSignature bool
 }
 
 // Enum is used as an input to the Enum method.
@@ -89,27 +134,81 @@ type Enum struct {
 }
 
 // Parse parses a struct and adds it to the builder.
+// With views in the config, the structs are parsed once for each view.
 func (g *Goty) Parse(elems ...any) *Goty {
+	if len(g.config.Views) > 0 {
+		return g.parseViews(elems)
+	}
+
 	for _, elem := range elems {
 		if elem == nil {
 			continue
 		}
 
-		typ := getType(elem)
+		typ, val := getType(elem), g.getSample(elem)
 		if typ.Kind() == reflect.Ptr {
-			typ = typ.Elem()
+			typ, val = typ.Elem(), sampleElem(val)
 		}
 
-		if typ.Kind() != reflect.Struct {
-			panic("expected a struct, got " + typ.String())
+		if typ.Kind() == reflect.Struct {
+			g.parseStruct(typ, val)
+		} else {
+			g.parseRoot(typ, val)
 		}
-
-		g.parseStruct(typ)
 	}
 
 	return g
 }
 
+// parseRoot adds a non-struct type to the builder as a typescript type alias.
+// Unnamed types, like []Item, and predeclared types, like int and error, have no alias;
+// only the types they reference are added.
+func (g *Goty) parseRoot(typ reflect.Type, val reflect.Value) {
+	member := &StructMember{}
+
+	switch {
+	case g.structTypes[typ] != nil:
+		return // Enums, and types we already parsed.
+	case typ.Name() == "", typ.PkgPath() == "":
+		g.parseValue(&DataStruct{Type: typ, doc: g.config, ovr: g.config.override(typ)}, typ, member, val)
+	default:
+		data := g.parseAlias(typ, member, val)
+		if member.unsupported && g.unsupportedRoot(typ) {
+			g.forgetAlias(data)
+		}
+
+		return
+	}
+
+	if member.unsupported {
+		g.unsupportedRoot(typ)
+	}
+}
+
+// unsupportedRoot applies the override's Unsupported policy to a root type the marshaller cannot encode.
+// Returns true if the type is omitted. Otherwise its alias is `never`.
+func (g *Goty) unsupportedRoot(typ reflect.Type) bool {
+	switch g.config.override(typ).Unsupported {
+	case UnsupportedFail:
+		panic(fmt.Errorf("%s: %w", typ, ErrUnsupported))
+	case UnsupportedNever:
+		g.warn(typ.String() + " cannot be marshalled, marked never")
+		return false
+	case UnsupportedOmit:
+		fallthrough
+	default:
+		g.warn(typ.String() + " cannot be marshalled, omitted")
+		return true
+	}
+}
+
+// forgetAlias removes an alias from the output.
+func (g *Goty) forgetAlias(data *DataStruct) {
+	delete(g.structTypes, data.Type)
+	delete(g.structNames, data.Name)
+	g.output = slices.DeleteFunc(g.output, func(output *DataStruct) bool { return output == data })
+}
+
 // Enums adds enums to the builder. The input is enum name and value pairs.
 // Add enums before parsing the structs that use them.
 func (g *Goty) Enums(enums ...[]Enum) *Goty {
@@ -162,8 +261,14 @@ func (g *Goty) enum(enum []Enum) {
 // parseStruct adds a struct to the builder if it doesn't already exist.
 // It will also add a unique suffix if the struct name is already taken.
 // It returns the struct data that is used as a typescript interface.
-func (g *Goty) parseStruct(elem reflect.Type) *DataStruct {
+// The val is a sample of the struct, and it's only valid when samples are enabled.
+func (g *Goty) parseStruct(elem reflect.Type, val reflect.Value) *DataStruct {
 	if v, ok := g.structTypes[elem]; ok {
+		g.mergeSample(v, val)
+		return v
+	}
+
+	if v := g.parseGeneric(elem); v != nil {
 		return v
 	}
 
@@ -189,103 +294,371 @@ func (g *Goty) parseStruct(elem reflect.Type) *DataStruct {
 		g.structNames[name] = true
 		g.output = append(g.output, data)
 		g.pkgPaths[elem.PkgPath()] = struct{}{}
+		// Type parameters of a generic interface do not belong to other interfaces.
+		g.withTypeArgs(nil, nil, func() {
+			g.addStructMembers(data, elem, val)
+		})
+
+		return data
 	}
 
-	g.addStructMembers(data, elem)
+	g.addStructMembers(data, elem, val)
 
 	return data
 }
 
-// addStructMembers loops through the fields of a struct and adds them to the builder.
-func (g *Goty) addStructMembers(data *DataStruct, field reflect.Type) {
-	for idx := range field.NumField() { // Loop each struct member
-		elem := field.Field(idx)
-		ovr := g.config.override(elem.Type)
-		tagval := strings.Split(elem.Tag.Get(ovr.Tag), ",")
+// addStructMembers adds the visible fields of a struct to the builder.
+// Embedded structs are extended or flattened depending on the override's Embed setting.
+// Flattened structs have their visible members added to this struct.
+func (g *Goty) addStructMembers(data *DataStruct, typ reflect.Type, val reflect.Value) {
+	fields := g.structFields(typ)
+	extends := g.findExtends(typ, fields)
+
+	for idx := range typ.NumField() {
+		omit, ok := extends[idx]
+		if !ok {
+			continue
+		}
+
+		name := g.parseStruct(derefType(typ.Field(idx).Type), sampleIndex(val, []int{idx})).Name
+		if len(omit) > 0 {
+			name = "Omit<" + name + ", " + strings.Join(omit, " | ") + ">"
+		}
+
+		if typ.Field(idx).Type.Kind() == reflect.Ptr {
+			// The json package leaves out every promoted member when the pointer is nil.
+			name = "Partial<" + name + ">"
+		}
+
+		data.Extends = append(data.Extends, name)
+	}
+
+	for _, field := range fields {
+		if _, ok := extends[field.index[0]]; ok {
+			continue
+		}
+
+		if field.goty.err != nil {
+			panic(fmt.Errorf("%s: %w", fieldPath(data, typ, field.index), field.goty.err))
+		}
+
+		fieldOvr := g.fieldOverride(data, typ, field)
+		if fieldOvr.Skip {
+			continue
+		}
+
+		if member := g.newMember(data, typ, field, val, fieldOvr); member != nil {
+			data.Members = append(data.Members, member)
+		}
+	}
+
+	if g.config.Profile == ProfileXML {
+		data.Members = nestMembers(data.Members)
+	}
+
+	for _, member := range data.Members {
+		if member.IndexSignature {
+			member.Type = indexSignature(data, member)
+		}
+	}
+}
+
+// findExtends returns the embedded struct fields that are extended, and the member names to omit from each.
+// Members are omitted when they are shadowed by, or conflict with, members from the parent struct.
+func (g *Goty) findExtends(typ reflect.Type, fields []structField) map[int][]string {
+	extends := make(map[int][]string)
+	mode := g.config.override(typ).Embed
 
-		name := tagval[0]
-		if name == "-" || !elem.IsExported() {
+	for idx := range typ.NumField() {
+		if _, explore, _ := g.newStructField(typ, nil, idx); !explore {
 			continue
-		} else if name == "" {
-			name = elem.Name
 		}
 
-		member := &StructMember{
-			Name:     g.stripBadChars(name, elem.Type),
-			doc:      g.config, // hard to attach this later.
-			Member:   elem,
-			parent:   data,
-			ovr:      ovr,
-			Optional: ovr.Optional,
-			Type:     ovr.Type,
+		own := g.structFields(derefType(typ.Field(idx).Type))
+		if len(own) == 0 {
+			continue // Nothing to extend, ie. sync.Mutex.
 		}
 
+		hidden := hiddenFields(idx, own, fields)
+
+		switch {
+		case mode == EmbedFlatten:
+		case mode == EmbedExtends, len(hidden) == 0:
+			extends[idx] = nil
+		case mode == EmbedOmit:
+			extends[idx] = hidden
+		case mode == EmbedAuto:
+			// Flatten it, because extending would produce the wrong members.
+		}
+	}
+
+	return extends
+}
+
+// hiddenFields returns the quoted names of the embedded struct's own fields
+// that are not visible through the embedded struct field at idx.
+func hiddenFields(idx int, own, fields []structField) []string {
+	hidden := []string{}
+
+	for _, embedded := range own {
+		if !slices.ContainsFunc(fields, func(field structField) bool {
+			return field.index[0] == idx && field.name == embedded.name && slices.Equal(field.index[1:], embedded.index)
+		}) {
+			hidden = append(hidden, quote(embedded.name))
+		}
+	}
+
+	return hidden
+}
+
+// newMember creates a struct member from a visible struct field.
+// Returns nil if the member is left out, because the marshaller cannot encode it.
+//
+//nolint:funlen // It's mostly a list of rules.
+func (g *Goty) newMember(
+	data *DataStruct, typ reflect.Type, field structField, val reflect.Value, fieldOvr FieldOverride,
+) *StructMember {
+	elem := field.field
+	ovr := g.config.override(elem.Type)
+
+	if fieldOvr.Comment != "" {
+		// The member prints the override's comment. Copy it so the type's override is not changed.
+		copied := *ovr
+		copied.Comment, ovr = fieldOvr.Comment, &copied
+	}
+
+	parent := data
+	if len(field.index) > 1 {
+		// This member came from an embedded struct; its docs are in that struct.
+		embed := typ.FieldByIndex(field.index[:len(field.index)-1])
+		parent = &DataStruct{Name: data.Name, Type: derefType(embed.Type), GoName: data.GoName, doc: data.doc, ovr: data.ovr}
+	}
+
+	member := &StructMember{
+		Name:     field.name,
+		doc:      g.config, // hard to attach this later.
+		Member:   elem,
+		parent:   parent,
+		ovr:      ovr,
+		Optional: ovr.Optional,
+		Type:     ovr.Type,
+	}
+
+	if fieldOvr.Type != "" {
+		member.Type = fieldOvr.Type // The field override wins over the type override.
+	}
+
+	switch {
+	case field.fallback:
+		// json v2 puts unknown object members here; they are next to the other members.
+		member.IndexSignature, member.Optional = true, false
 		if member.Type == "" {
-			// We only parse the member if it didn't have a type override.
-			member.Type, member.Optional = g.parseMember(data, elem.Type, member)
+			member.Type = g.parseFallback(data, elem.Type, member, sampleIndex(val, field.index))
 		}
 
-		for _, tag := range tagval {
-			if tag == "omitempty" {
-				member.Optional = true
-			}
+		return member
+	case member.Type == "":
+		// We only parse the member if it didn't have a type override.
+		member.Type, member.Nullable = g.parseValue(data, elem.Type, member, sampleIndex(val, field.index))
+		if field.format != "" {
+			g.parseFormat(data, field, member, sampleIndex(val, field.index))
 		}
+	}
+
+	if member.unsupported {
+		return g.unsupported(data, typ, field, member)
+	}
+
+	if g.omitted(field) {
+		// Empty values are left out, and nil is empty, so it's never null.
+		member.Optional, member.Nullable = true, false
+	}
 
-		data.addMember(member)
+	if throughPointer(typ, field.index) {
+		member.Optional = true // Promoted members are left out when the embedded pointer is nil.
 	}
+
+	if field.hasOpt("string") && ovr.Type == "" && fieldOvr.Type == "" && g.quotable(elem.Type) {
+		// The json package encodes these scalars as strings.
+		member.Type = "string"
+	}
+
+	if fieldOvr.Name != "" {
+		member.Name = fieldOvr.Name
+	}
+
+	member.Optional = member.Optional || fieldOvr.Optional
+	member.Nullable = member.Nullable || fieldOvr.Nullable
+	member.Readonly = fieldOvr.Readonly
+
+	return member
 }
 
-// addMember adds a member to the struct.
-// If the member is an anonymous struct, it extends the parent struct.
-// Otherwise, it adds the member to the struct.
-func (d *DataStruct) addMember(member *StructMember) {
-	if member.Member.Anonymous && (member.Member.Type.Kind() == reflect.Struct ||
-		member.Member.Type.Kind() == reflect.Ptr && member.Member.Type.Elem().Kind() == reflect.Struct) {
-		d.Extends = append(d.Extends, member.Type)
-	} else {
-		d.Members = append(d.Members, member)
+// fieldOverride returns the field override for a struct field, on top of the goty tag options.
+// Promoted fields are found by the name of the struct they came from.
+func (g *Goty) fieldOverride(data *DataStruct, typ reflect.Type, field structField) FieldOverride {
+	if len(g.config.FieldOverrides) == 0 {
+		return field.goty.override
+	}
+
+	owner := fieldOwner(typ, field.index)
+	key := owner.PkgPath() + "." + owner.Name()
+
+	if owner.Name() == "" && data.root != nil {
+		// Anonymous structs have no name, so use the path from the named struct.
+		key = data.root.PkgPath() + "." + data.root.Name() + "." + strings.Join(data.path[1:], ".")
+	}
+
+	return field.goty.override.merge(g.config.FieldOverrides[key+"."+field.field.Name])
+}
+
+// throughPointer returns true if a promoted field is in a struct that is embedded by pointer.
+func throughPointer(typ reflect.Type, index []int) bool {
+	for _, idx := range index[:len(index)-1] {
+		field := typ.Field(idx)
+		if field.Type.Kind() == reflect.Ptr {
+			return true
+		}
+
+		typ = field.Type
+	}
+
+	return false
+}
+
+// fieldOwner returns the struct that declares the field at the index. That's an embedded struct for promoted fields.
+func fieldOwner(typ reflect.Type, index []int) reflect.Type {
+	if len(index) < 2 { //nolint:mnd // A promoted field has a parent index.
+		return typ
+	}
+
+	return derefType(typ.FieldByIndex(index[:len(index)-1]).Type)
+}
+
+// unsupported applies the override's Unsupported policy to a member the marshaller cannot encode.
+// The member is returned if it stays in the interface.
+func (g *Goty) unsupported(data *DataStruct, typ reflect.Type, field structField, member *StructMember) *StructMember {
+	path := fieldPath(data, typ, field.index)
+
+	switch g.config.override(typ).Unsupported {
+	case UnsupportedFail:
+		panic(fmt.Errorf("%s: %s: %w", path, field.field.Type, ErrUnsupported))
+	case UnsupportedNever:
+		g.warn(path + ": " + field.field.Type.String() + " cannot be marshalled, marked never")
+		member.Type, member.Optional, member.Nullable = "never", true, false
+
+		return member
+	case UnsupportedOmit:
+		fallthrough
+	default:
+		g.warn(path + ": " + field.field.Type.String() + " cannot be marshalled, omitted")
+		return nil
+	}
+}
+
+// fieldPath returns the go path to a struct field for messages, ie. golift.io/goty.Config.Docs.
+// The index goes through embedded structs.
+func fieldPath(data *DataStruct, typ reflect.Type, index []int) string {
+	path := data.GoName
+	if path == "" && data.root != nil {
+		path = data.root.PkgPath() + "." + data.root.Name() + "." + strings.Join(data.path[1:], ".")
+	} else if path == "" {
+		path = typ.String()
+	}
+
+	for idx := range index {
+		path += "." + typ.FieldByIndex(index[:idx+1]).Name
+	}
+
+	return path
+}
+
+// warn saves a warning once. Some structs are parsed more than once, like generic instances.
+func (g *Goty) warn(warning string) {
+	if !slices.Contains(g.warnings, warning) {
+		g.warnings = append(g.warnings, warning)
 	}
 }
 
 // parseMember returns the typescript type for a given go type.
-// It also returns a boolean indicating if the type is optional.
+// It also returns a boolean indicating if the type is nullable.
 // Fully recursive.
+func (g *Goty) parseMember(parent *DataStruct, field reflect.Type, member *StructMember) (string, bool) {
+	return g.parseValue(parent, field, member, reflect.Value{})
+}
+
+// parseValue is parseMember with a sample value. The value is only valid when samples are enabled.
+func (g *Goty) parseValue(parent *DataStruct, field reflect.Type, member *StructMember, val reflect.Value) (string, bool) {
+	if name, ok := g.typeArgs[field]; ok {
+		// This happens while parsing a generic interface.
+		return name, g.isNilable(field)
+	}
+
+	if data := g.structTypes[field]; data != nil {
+		// This happens when there was a matching enum provided, or an alias, union or struct.
+		g.mergeSample(data, val)
+		return data.Name, g.isNilable(field) && !isMarshaler(field)
+	}
+
+	return g.parseType(parent, field, member, val)
+}
+
+// parseType does the work for parseMember after the known types are checked.
 //
 //nolint:cyclop // This is a complex function, but really it's not that bad.
-func (g *Goty) parseMember(parent *DataStruct, field reflect.Type, member *StructMember) (string, bool) {
-	if g.structTypes[field] != nil {
-		// This happens when there was a matching enum provided.
-		return g.structTypes[field].Name, false
+func (g *Goty) parseType(parent *DataStruct, field reflect.Type, member *StructMember, val reflect.Value) (string, bool) {
+	if ovr := g.config.override(field); ovr.Type != "" {
+		// Type overrides work on nested types too, like slice and map elements.
+		return ovr.Type, false
+	}
+
+	if name, ok := g.profileType(field); ok {
+		return name, false
+	}
+
+	if name, ok := g.config.mapping(field); ok {
+		return name, field.Kind() == reflect.Interface
+	}
+
+	if name, ok := g.checkMarshaler(field); ok {
+		return name, false
 	}
 
 	switch field.Kind() {
 	case reflect.Ptr:
-		s, _ := g.parseMember(parent, field.Elem(), member)
+		s, _ := g.parseValue(parent, field.Elem(), member, sampleElem(val))
 		return s, true
 	case reflect.Struct:
-		return g.checkStruct(field, member), false
-	case reflect.Array, reflect.Slice:
-		return g.parseSlice(parent, field, member), true
+		return g.checkStruct(field, member, val), false
+	case reflect.Array:
+		return g.parseArray(parent, field, member, val), false
+	case reflect.Slice:
+		return g.parseSlice(parent, field, member, val), g.isNilable(field)
 	case reflect.Map:
-		return g.parseMap(parent, field, member), true
+		if !g.isMapKey(field.Key()) {
+			member.unsupported = true // The marshaller returns an error for these.
+			return "never", false
+		}
+
+		return g.parseMap(parent, field, member, val), g.isNilable(field)
 	case reflect.Bool:
-		return "boolean", false
+		return g.parseScalar(field, "boolean", member), false
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
 		reflect.Float32, reflect.Float64, reflect.Uintptr:
-		return "number", false
+		return g.parseScalar(field, g.numberType(field), member), false
 	case reflect.String:
-		return "string", false
+		return g.parseScalar(field, "string", member), false
 	case reflect.Interface:
-		fallthrough
-	case reflect.Func:
-		fallthrough
-	case reflect.Chan:
-		fallthrough
-	case reflect.UnsafePointer:
-		fallthrough
-	case reflect.Complex64, reflect.Complex128:
-		fallthrough
+		if val.IsValid() && !val.IsNil() { // Use the sample's concrete type.
+			name, _ := g.parseValue(parent, val.Elem().Type(), member, val.Elem())
+			return name, true
+		}
+
+		return "any", true
+	case reflect.Func, reflect.Chan, reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		member.unsupported = true // The marshaller returns an error for these.
+		return "never", false
 	case reflect.Invalid:
 		fallthrough
 	default:
@@ -293,63 +666,270 @@ func (g *Goty) parseMember(parent *DataStruct, field reflect.Type, member *Struc
 	}
 }
 
+var (
+	jsonMarshaler = reflect.TypeFor[json.Marshaler]()
+	textMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+// isMarshaler returns true if the type marshals itself.
+func isMarshaler(field reflect.Type) bool {
+	return implements(field, jsonMarshaler) || implements(field, textMarshaler)
+}
+
+// implements returns true if the type, or a pointer to the type, implements the interface.
+func implements(field, iface reflect.Type) bool {
+	return field.Implements(iface) || reflect.PointerTo(field).Implements(iface)
+}
+
 // checkStruct provides some logic to detect special struct types.
-// Those include time.Time/Duration and embedded structs. Do we need others?
-func (g *Goty) checkStruct(field reflect.Type, member *StructMember) string {
-	switch field.String() {
-	case "time.Time":
-		return "Date"
-	case "time.Duration":
+// Those include anonymous structs. Do we need others?
+func (g *Goty) checkStruct(field reflect.Type, member *StructMember, val reflect.Value) string {
+	if field.Name() == "" {
+		return g.parseAnonymous(field, member, val)
+	}
+
+	return g.parseStruct(field, val).Name
+}
+
+// parseAnonymous returns the typescript type for an anonymous struct.
+// The struct is hoisted into its own interface if the override asks for it.
+// Otherwise its members are added to the member, and printed inline.
+func (g *Goty) parseAnonymous(field reflect.Type, member *StructMember, val reflect.Value) string {
+	data := &DataStruct{
+		Type:    field,
+		Members: make([]*StructMember, 0),
+		doc:     g.config,
+		ovr:     g.config.override(field),
+	}
+
+	data.root, data.path = anonymousPath(member)
+
+	// Type parameters cannot be used in a hoisted interface that is not generic, so those stay inline.
+	if !data.ovr.Hoist || data.root == nil || len(g.typeArgs) > 0 {
+		g.addStructMembers(data, field, val)
+		member.Members = append(member.Members, data.Members...)
+		member.Extends = append(member.Extends, data.Extends...)
+
+		return "" // anonymous structs don't have names; deal with it.
+	}
+
+	data.Name = g.makeStructName(field, data.ovr.HoistNamer(data.path))
+	data.GoName = data.root.PkgPath() + "." + data.root.Name() + "." + strings.Join(data.path[1:], ".")
+	// Identical anonymous structs are the same type, so they share the first interface.
+	g.structTypes[field] = data
+	g.structNames[data.Name] = true
+	g.output = append(g.output, data)
+	g.withTypeArgs(nil, nil, func() {
+		g.addStructMembers(data, field, val)
+	})
+
+	return data.Name
+}
+
+// anonymousPath returns the named go type, and the path to an anonymous struct member in it.
+func anonymousPath(member *StructMember) (reflect.Type, []string) {
+	switch parent := member.parent; {
+	case parent == nil || member.Member.Name == "":
+		return nil, nil
+	case parent.root != nil:
+		return parent.root, append(slices.Clone(parent.path), member.Member.Name)
+	case parent.Type != nil && parent.Type.Name() != "" && parent.Name != "":
+		// The go name, because the interface name already has the Namer and view affixes.
+		return parent.Type, []string{genericName(parent.Type.Name()), member.Member.Name}
+	default:
+		return nil, nil
+	}
+}
+
+// parseScalar returns the typescript type for a boolean, number or string.
+// Named go types become typescript type aliases if the override asks for it.
+func (g *Goty) parseScalar(field reflect.Type, name string, member *StructMember) string {
+	ovr := g.config.override(field)
+	if ovr.ScalarAlias == ScalarAliasNever || field.Name() == "" || field.PkgPath() == "" ||
+		g.structTypes[field] != nil { // The alias is being parsed and needs the basic type.
+		return name
+	}
+
+	return g.parseAlias(field, member, reflect.Value{}).Name
+}
+
+// numberType returns the typescript type for a go number.
+// int64 and uint64 may not fit in a javascript number, so the override can change them.
+// int, uint and uintptr are left alone, so the output does not depend on the architecture.
+func (g *Goty) numberType(field reflect.Type) string {
+	if kind := field.Kind(); kind != reflect.Int64 && kind != reflect.Uint64 {
 		return "number"
 	}
 
-	structMember := g.parseStruct(field)
-	if structMember.Name == "" { // Embedded struct.
-		member.Members = append(member.Members, structMember.Members...)
-		member.Extends = append(member.Extends, structMember.Extends...)
+	switch g.config.override(field).Int64 {
+	case Int64BigInt:
+		return "bigint"
+	case Int64String:
+		return "string"
+	case Int64Number:
+		fallthrough
+	default:
+		return "number"
+	}
+}
+
+// parseAlias adds a named go type to the builder as a typescript type alias.
+// The alias is a branded type if it's a scalar and the override asks for it.
+func (g *Goty) parseAlias(field reflect.Type, member *StructMember, val reflect.Value) *DataStruct {
+	data := &DataStruct{
+		Name:   g.getStructName(field),
+		Type:   field,
+		GoName: field.PkgPath() + "." + field.Name(),
+		doc:    g.config,
+		ovr:    g.config.override(field),
+	}
+
+	// Add it before parsing, so recursive types find themselves.
+	g.structTypes[field] = data
+	g.structNames[data.Name] = true
+	g.output = append(g.output, data)
+	g.pkgPaths[field.PkgPath()] = struct{}{}
 
-		return "" // embedded structs don't have names; deal with it.
+	data.Alias, _ = g.parseType(data, field, member, val)
+	if data.ovr.ScalarAlias == ScalarAliasBranded && isScalar(field) {
+		data.Alias += ` & { __brand: "` + data.Name + `" }`
 	}
 
-	return structMember.Name
+	return data
 }
 
 // parseSlice returns the typescript type for a given go slice.
-func (g *Goty) parseSlice(parent *DataStruct, field reflect.Type, member *StructMember) string {
+func (g *Goty) parseSlice(parent *DataStruct, field reflect.Type, member *StructMember, val reflect.Value) string {
 	// Go marshalls a byte slice into a base64 encoded string.
-	if field.String() == "[]uint8" {
+	// Byte arrays are not included; those become number arrays.
+	if g.isBytes(field) {
 		return "string"
 	}
 
-	name, optional := g.parseMember(parent, field.Elem(), member)
-	if optional && g.config.override(field).NullSlicePointers {
-		name = "(null | " + name + ")"
+	return wrapUnion(g.parseElem(parent, field, member, sampleElems(val)...)) + "[]"
+}
+
+// parseArray returns the typescript type for a given go array.
+// Byte arrays are treated like any other array, because that's what the json package does.
+// json v2 encodes them as base64 strings.
+func (g *Goty) parseArray(parent *DataStruct, field reflect.Type, member *StructMember, val reflect.Value) string {
+	if g.isByteArray(field) {
+		return "string"
 	}
 
-	// This doesn't really produce valid typescript. Any ideas?
-	// size := ""
-	// if field.Kind() == reflect.Array {
-	// 	size = strconv.Itoa(field.Len())
-	// }
-	// return name + "[" + size + "]"
-	return name + "[]"
+	return g.parseTuple(parent, field, member, val)
 }
 
-// parseMap returns the typescript type for a given go map.
-func (g *Goty) parseMap(parent *DataStruct, field reflect.Type, member *StructMember) string {
-	// Parse both sides of the map.
-	key, keyOptional := g.parseMember(parent, field.Key(), member)
-	val, valOptional := g.parseMember(parent, field.Elem(), member)
+// parseTuple returns the typescript type for the elements in a go array.
+// Arrays have a fixed length, so they become tuples unless they are too long.
+func (g *Goty) parseTuple(parent *DataStruct, field reflect.Type, member *StructMember, val reflect.Value) string {
+	maxLen := g.config.override(field).MaxTupleLength
+	if maxLen < 0 || field.Len() > maxLen {
+		return wrapUnion(g.parseElem(parent, field, member, sampleElems(val)...)) + "[]"
+	}
+
+	name := g.parseElem(parent, field, member)
+	elems := make([]string, field.Len())
+
+	for idx := range elems {
+		elems[idx] = name
+		if val.IsValid() { // Each sample may have its own type.
+			elems[idx] = g.parseElem(parent, field, member, val.Index(idx))
+		}
+	}
+
+	return "[" + strings.Join(elems, ", ") + "]"
+}
 
-	if keyOptional {
-		key = "null | " + key
+// parseElem returns the typescript type for the elements in a slice or array.
+// The vals are samples of the elements; their types are combined into a union.
+func (g *Goty) parseElem(parent *DataStruct, field reflect.Type, member *StructMember, vals ...reflect.Value) string {
+	name, nullable := g.parseValues(parent, field.Elem(), member, vals)
+	if nullable && g.config.override(field).NullSlicePointers {
+		name = "null | " + name
 	}
 
-	if valOptional {
+	return name
+}
+
+// omitsEmpty returns true if the json omitempty option can leave out a value of this type.
+// Structs are never empty. Arrays are only empty if they have no length.
+func omitsEmpty(field reflect.Type) bool {
+	switch field.Kind() {
+	case reflect.Struct:
+		return false
+	case reflect.Array:
+		return field.Len() == 0
+	default:
+		return true
+	}
+}
+
+// isScalar returns true if the type is a boolean, number or string.
+func isScalar(field reflect.Type) bool {
+	switch field.Kind() {
+	case reflect.Bool, reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Uintptr:
+		return true
+	default:
+		return false
+	}
+}
+
+// isQuotable returns true if the json `string` tag option works on the type.
+// That's a scalar, or an unnamed pointer to a scalar.
+func isQuotable(field reflect.Type) bool {
+	if field.Name() == "" && field.Kind() == reflect.Ptr {
+		field = field.Elem()
+	}
+
+	return isScalar(field) && field.Kind() != reflect.Uintptr
+}
+
+// isByteSlice returns true if the type is a slice the json package encodes as base64.
+func isByteSlice(field reflect.Type) bool {
+	return field.Kind() == reflect.Slice && field.Elem().Kind() == reflect.Uint8 && !isMarshaler(field.Elem())
+}
+
+// parseMap returns the typescript type for a given go map.
+// JSON object keys are always strings. Maps with enum keys may not have every key.
+func (g *Goty) parseMap(parent *DataStruct, field reflect.Type, member *StructMember, sample reflect.Value) string {
+	val, valNullable := g.parseValues(parent, field.Elem(), member, sampleElems(sample))
+	if valNullable {
 		val = "null | " + val
 	}
 
-	return "Record<" + key + ", " + val + ">"
+	if enum := g.structTypes[field.Key()]; enum != nil && len(enum.Elements) > 0 {
+		return "Partial<Record<" + enum.Name + ", " + val + ">>"
+	}
+
+	return "Record<string, " + val + ">"
+}
+
+// isMapKey returns true if the marshaller can encode a map with this key type.
+// The json package allows strings, integers and text marshalers. json v2 also allows floats.
+// toml only allows strings, xml allows nothing, and yaml and mapstructure allow anything.
+func (g *Goty) isMapKey(key reflect.Type) bool {
+	switch g.config.Profile {
+	case ProfileYAML, ProfileMapstructure:
+		return true
+	case ProfileTOML:
+		return key.Kind() == reflect.String || key.Implements(textMarshaler)
+	case ProfileXML:
+		return false // encoding/xml cannot marshal maps.
+	case ProfileJSON, ProfileJSONv2:
+	}
+
+	switch key.Kind() {
+	case reflect.String, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	case reflect.Float32, reflect.Float64:
+		return g.config.Profile == ProfileJSONv2 || key.Implements(textMarshaler)
+	default:
+		return key.Implements(textMarshaler)
+	}
 }
 
 // getStructName returns a unique, capitalized name for a struct by appending a number to the end.
@@ -360,20 +940,37 @@ func (g *Goty) parseMap(parent *DataStruct, field reflect.Type, member *StructMe
 // - Use the struct name without the package name.
 // - Make the name lowercase, uppercase, camelcase or snake_case.
 func (g *Goty) getStructName(elem reflect.Type) string {
+	return g.makeStructName(elem, genericName(elem.Name()))
+}
+
+// makeStructName does the work for getStructName. The goName is the go type name to start from.
+// Names that are reserved in typescript are treated like conflicts, and a warning is saved.
+func (g *Goty) makeStructName(elem reflect.Type, goName string) string {
 	ovr := g.config.override(elem)
-	name := ovr.Namer(elem, capitalizeFirstLetter(elem.Name()))
+	goName = g.viewName(elem, goName)
+	name := ovr.Namer(elem, capitalizeFirstLetter(goName))
 	name = g.stripBadChars(name, elem)
 	pkgParts := strings.Split(elem.PkgPath(), "/")
+	reserved := ""
+
+	if reservedNames[name] && ovr.UsePkgName != UsePkgNameAlways {
+		reserved = name
+	}
 
 	if ovr.UsePkgName == UsePkgNameAlways ||
-		(g.structNames[name] && ovr.UsePkgName == UsePkgNameOnConflict) {
+		(g.nameTaken(name) && ovr.UsePkgName == UsePkgNameOnConflict) {
 		// We have to pass the original element name back in here so any name changes are repeated.
-		name = ovr.Namer(elem, capitalizeFirstLetter(pkgParts[len(pkgParts)-1])+elem.Name())
+		name = ovr.Namer(elem, capitalizeFirstLetter(pkgParts[len(pkgParts)-1])+goName)
 	}
 
 	// Name is elem name, or base pkg name + elem name. If there is an override, use it.
 	if ovr.Name != "" {
 		name = ovr.Name
+		reserved = ""
+
+		if reservedNames[name] {
+			reserved = name
+		}
 	} else {
 		name = g.stripBadChars(name, elem)
 	}
@@ -385,13 +982,17 @@ func (g *Goty) getStructName(elem reflect.Type) string {
 
 	// Find a unique name for the struct by appending a number to the end.
 	for i := range 1000 {
-		if !g.structNames[name] {
+		if !g.nameTaken(name) {
 			break
 		}
 
 		name = base + strconv.Itoa(i)
 	}
 
+	if reserved != "" {
+		g.warn(elem.PkgPath() + "." + elem.Name() + ": " + reserved + " is a reserved typescript name, renamed to " + name)
+	}
+
 	return name
 }
 
@@ -401,11 +1002,39 @@ func capitalizeFirstLetter(str string) string {
 		return ""
 	}
 
-	return string(unicode.ToUpper(rune(str[0]))) + str[1:]
+	char, size := utf8.DecodeRuneInString(str)
+
+	return string(unicode.ToUpper(char)) + str[size:]
+}
+
+// quote returns a string as a typescript string literal, ie. "api-key".
+func quote(str string) string {
+	var buf strings.Builder
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(str) // strings always encode.
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// isIdentifier returns true if the name can be a typescript property name without quotes.
+func isIdentifier(name string) bool {
+	for idx, char := range name {
+		switch {
+		case char == '$', char == '_', unicode.IsLetter(char):
+		case idx > 0 && unicode.IsDigit(char):
+		default:
+			return false
+		}
+	}
+
+	return name != ""
 }
 
 // stripBadChars strips underscores, dashes, dots, colons, slashes,
 // and other invalid typescript interface name characters from a string.
+// This is only used on interface and enum names. Member names are quoted instead.
 func (g *Goty) stripBadChars(name string, typ reflect.Type) string {
 	ovr := g.config.override(typ)
 	if ovr.KeepBadChars && ovr.KeepUnderscores {
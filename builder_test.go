@@ -0,0 +1,928 @@
+package goty_test
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"golift.io/goty"
+)
+
+type testBase struct {
+	ID   int `json:"id"`
+	Kind string
+}
+
+type testOther struct {
+	Kind  int
+	Owner string `json:"owner"`
+}
+
+type testTagged struct {
+	Type string `json:"Kind"`
+}
+
+type testUnexported struct {
+	Inner string `json:"inner"`
+}
+
+type TestLabel string
+
+type testLabel string
+
+type testDeep struct {
+	testBase
+
+	Deep string `json:"deep"`
+}
+
+type (
+	// testTaggedEmbed has an embedded struct with a tag, so it's a named member.
+	testTaggedEmbed struct {
+		testBase `json:"base"`
+
+		Name string `json:"name"`
+	}
+	// testShadow has a member that shadows one in the embedded struct.
+	testShadow struct {
+		testBase
+
+		Kind float64
+	}
+	// testConflict has two embedded structs with the same member name at the same depth.
+	testConflict struct {
+		testBase
+		testOther
+	}
+	// testTaggedWins has two embedded structs with the same name, and only one is tagged.
+	testTaggedWins struct {
+		testTagged
+		testBase
+	}
+	// testUnexportedEmbed embeds unexported structs and a pointer to one.
+	testUnexportedEmbed struct {
+		testUnexported
+		*testDeep
+	}
+	// testNonStruct embeds exported and unexported non-struct types.
+	testNonStruct struct {
+		TestLabel
+		testLabel
+
+		Skip string `json:"-"`
+	}
+	// testQuoted has member names that are not typescript identifiers.
+	testQuoted struct {
+		Dash    string `json:"-,"`
+		APIKey  string `json:"api-key"`
+		Dotted  string `json:"x.y"`
+		Unicode string `json:"éclair"`
+	}
+	// testDepth has a conflict that is won by the shallower member.
+	testDepth struct {
+		testDeep
+		testOther
+
+		Deep int
+	}
+)
+
+// TestJSONFields compares the members goty finds with the members encoding/json produces.
+func TestJSONFields(t *testing.T) {
+	t.Parallel()
+
+	tests := []any{
+		testTaggedEmbed{},
+		testShadow{},
+		testConflict{},
+		testTaggedWins{},
+		testUnexportedEmbed{testDeep: &testDeep{}},
+		testUnexportedEmbed{}, // The promoted members are left out, so they are optional.
+		testNonStruct{},
+		testQuoted{},
+		testDepth{},
+	}
+
+	modes := map[string]goty.Embed{
+		"auto":    goty.EmbedAuto,
+		"flatten": goty.EmbedFlatten,
+		"omit":    goty.EmbedOmit,
+	}
+
+	for mode, embed := range modes {
+		for _, test := range tests {
+			testJSONFields(t, mode, embed, test)
+		}
+	}
+}
+
+func testJSONFields(t *testing.T, mode string, embed goty.Embed, test any) {
+	t.Helper()
+
+	name := reflect.TypeOf(test).Name()
+	t.Run(mode+"/"+name+"/"+strconv.FormatBool(reflect.ValueOf(test).IsZero()), func(t *testing.T) {
+		t.Parallel()
+
+		data, err := json.Marshal(test)
+		if err != nil {
+			t.Fatalf("marshalling %s: %v", name, err)
+		}
+
+		wire := map[string]json.RawMessage{}
+		if err := json.Unmarshal(data, &wire); err != nil {
+			t.Fatalf("unmarshalling %s: %v", name, err)
+		}
+
+		want := make([]string, 0, len(wire))
+		for key := range wire {
+			want = append(want, key)
+		}
+
+		slices.Sort(want)
+
+		config := &goty.Config{GlobalOverrides: goty.Override{Embed: embed}}
+
+		// Every member on the wire must be in the interface, and every required member must be on the wire.
+		values := goty.NewGoty(config).Parse(test).Values()
+		got, required := tsKeys(values, capitalize(name)), tsRequired(values, capitalize(name))
+
+		if !isSubset(want, got) || !isSubset(required, want) {
+			t.Errorf("%s: members do not match encoding/json\n got: %v\nrequired: %v\nwant: %v", name, got, required, want)
+		}
+	})
+}
+
+// tsKeys returns the sorted member names of an interface, including the members it extends.
+// Extended interfaces may be wrapped in Omit<Name, "a" | "b"> and Partial<Name>.
+func tsKeys(values []*goty.DataStruct, name string) []string {
+	keys := []string{}
+
+	if inner, ok := strings.CutPrefix(name, "Partial<"); ok {
+		return tsKeys(values, strings.TrimSuffix(inner, ">"))
+	}
+
+	if inner, ok := strings.CutPrefix(name, "Omit<"); ok {
+		base, list, _ := strings.Cut(strings.TrimSuffix(inner, ">"), ", ")
+
+		for _, key := range tsKeys(values, base) {
+			if !slices.Contains(strings.Split(list, " | "), strconv.Quote(key)) {
+				keys = append(keys, key)
+			}
+		}
+
+		return keys
+	}
+
+	for _, data := range values {
+		if data.Name != name {
+			continue
+		}
+
+		for _, member := range data.Members {
+			keys = append(keys, member.Name)
+		}
+
+		for _, extends := range data.Extends {
+			keys = append(keys, tsKeys(values, extends)...)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// tsRequired returns the sorted member names of an interface that are not optional.
+// Members of a Partial<Name> interface are all optional.
+func tsRequired(values []*goty.DataStruct, name string) []string {
+	keys := []string{}
+
+	for _, key := range tsKeys(values, name) {
+		if !strings.HasPrefix(name, "Partial<") && isRequired(values, name, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+// isRequired returns true if the member is not optional in the interface, or the interfaces it extends.
+func isRequired(values []*goty.DataStruct, name, key string) bool {
+	name, _, _ = strings.Cut(strings.TrimPrefix(name, "Omit<"), ", ")
+	if strings.HasPrefix(name, "Partial<") {
+		return false
+	}
+
+	for _, data := range values {
+		if data.Name != name {
+			continue
+		}
+
+		for _, member := range data.Members {
+			if member.Name == key {
+				return !member.Optional
+			}
+		}
+
+		for _, extends := range data.Extends {
+			if slices.Contains(tsKeys(values, extends), key) {
+				return isRequired(values, extends, key)
+			}
+		}
+	}
+
+	return false
+}
+
+// isSubset returns true if every item in the first list is in the second.
+func isSubset(items, list []string) bool {
+	for _, item := range items {
+		if !slices.Contains(list, item) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func capitalize(name string) string {
+	char, size := utf8.DecodeRuneInString(name)
+	return string(unicode.ToUpper(char)) + name[size:]
+}
+
+type (
+	// Date shadows the typescript global that time.Time becomes.
+	Date struct {
+		Day int `json:"day"`
+	}
+	// Node is a DOM global. goty never uses it, so shadowing it is harmless and it keeps its name.
+	Node struct {
+		Name string `json:"name"`
+	}
+	testReserved struct {
+		Date    Date      `json:"date"`
+		Created time.Time `json:"created"`
+		Node    Node      `json:"node"`
+	}
+)
+
+// TestReservedNames makes sure a go type with a reserved name gets renamed, and a warning.
+func TestReservedNames(t *testing.T) {
+	t.Parallel()
+
+	builder := goty.NewGoty(nil).Parse(testReserved{})
+
+	names := []string{}
+	for _, data := range builder.Values() {
+		names = append(names, data.Name)
+	}
+
+	want := []string{"TestReserved", "GotytestDate", "Node"}
+	if !slices.Equal(names, want) {
+		t.Errorf("wrong interface names\n got: %v\nwant: %v", names, want)
+	}
+
+	if len(builder.Warnings()) != 1 || !strings.Contains(builder.Warnings()[0], "Date is a reserved typescript name") {
+		t.Errorf("expected one reserved name warning, got: %v", builder.Warnings())
+	}
+}
+
+type testKey struct{ id int }
+
+func (k testKey) MarshalText() ([]byte, error) { return []byte(strconv.Itoa(k.id)), nil }
+
+type testMapKeys struct {
+	Strings  map[string]int          `json:"strings"`
+	Ints     map[int]string          `json:"ints"`
+	Texts    map[testKey]bool        `json:"texts"`
+	Weekdays map[time.Weekday]string `json:"weekdays"`
+	Floats   map[float64]string      `json:"floats"`
+	Anys     map[any]string          `json:"anys"`
+}
+
+// TestMapKeys checks map keys follow the json package rules.
+func TestMapKeys(t *testing.T) {
+	t.Parallel()
+
+	weekdays := []goty.Enum{{Name: "Sunday", Value: time.Sunday}, {Name: "Monday", Value: time.Monday}}
+	want := map[string]string{
+		"strings":  "Record<string, number>",
+		"ints":     "Record<string, string>",
+		"texts":    "Record<string, boolean>",
+		"weekdays": "Partial<Record<Weekday, string>>",
+		"floats":   "never",
+		"anys":     "never",
+	}
+
+	config := &goty.Config{GlobalOverrides: goty.Override{Unsupported: goty.UnsupportedNever}}
+
+	for _, data := range goty.NewGoty(config).Enums(weekdays).Parse(testMapKeys{}).Values() {
+		for _, member := range data.Members {
+			if member.Type != want[member.Name] {
+				t.Errorf("%s: wrong map type\n got: %s\nwant: %s", member.Name, member.Type, want[member.Name])
+			}
+		}
+	}
+}
+
+type testUnsupported struct {
+	testUnsupportedEmbed
+
+	Name     string          `json:"name"`
+	Callback func()          `json:"callback"`
+	Numbers  []complex128    `json:"numbers"`
+	Pointer  *chan int       `json:"pointer"`
+	Nested   struct{ F any } `json:"nested"`
+}
+
+type testUnsupportedEmbed struct {
+	Events chan string `json:"events"`
+}
+
+// TestUnsupported checks each Unsupported policy, and the warnings with the go field paths.
+func TestUnsupported(t *testing.T) {
+	t.Parallel()
+
+	builder := goty.NewGoty(nil).Parse(testUnsupported{})
+	if got := tsKeys(builder.Values(), "TestUnsupported"); !slices.Equal(got, []string{"name", "nested"}) {
+		t.Errorf("unsupported members were not omitted: %v", got)
+	}
+
+	want := []string{
+		"golift.io/goty_test.testUnsupportedEmbed.Events: chan string cannot be marshalled, omitted",
+		"golift.io/goty_test.testUnsupported.Callback: func() cannot be marshalled, omitted",
+		"golift.io/goty_test.testUnsupported.Numbers: []complex128 cannot be marshalled, omitted",
+		"golift.io/goty_test.testUnsupported.Pointer: *chan int cannot be marshalled, omitted",
+	}
+	if !slices.Equal(builder.Warnings(), want) {
+		t.Errorf("wrong warnings\n got: %q\nwant: %q", builder.Warnings(), want)
+	}
+
+	config := &goty.Config{GlobalOverrides: goty.Override{Unsupported: goty.UnsupportedNever}}
+	for _, member := range goty.NewGoty(config).Parse(testUnsupported{}).Values()[0].Members {
+		if member.Name != "name" && member.Name != "nested" && (member.Type != "never" || !member.Optional) {
+			t.Errorf("%s: expected an optional never member, got: %s", member.Name, member.Type)
+		}
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, goty.ErrUnsupported) {
+			t.Errorf("expected a panic with ErrUnsupported, got: %v", err)
+		}
+	}()
+
+	config = &goty.Config{GlobalOverrides: goty.Override{Unsupported: goty.UnsupportedFail}}
+	goty.NewGoty(config).Parse(testUnsupported{})
+}
+
+type testFieldOverrides struct {
+	testBase
+
+	Timeout time.Duration `json:"timeout"`
+	Other   time.Duration `json:"other"`
+	Secret  string        `json:"secret"`
+	Note    *string       `json:"note"`
+	Auth    struct {
+		User string `json:"user"`
+	} `json:"auth"`
+}
+
+func TestFieldOverrides(t *testing.T) {
+	t.Parallel()
+
+	if key := goty.FieldKey(&testFieldOverrides{}, "Kind"); key != "golift.io/goty_test.testBase.Kind" {
+		t.Errorf("promoted fields should use the embedded struct in the key, got: %s", key)
+	}
+
+	builder := goty.NewGoty(&goty.Config{FieldOverrides: goty.FieldOverrides{
+		goty.FieldKey(testFieldOverrides{}, "Timeout"):     {Type: "string", Comment: "A duration, ie. 1m30s."},
+		goty.FieldKey(testFieldOverrides{}, "Secret"):      {Skip: true},
+		goty.FieldKey(testFieldOverrides{}, "Note"):        {Name: "comment", Optional: true},
+		goty.FieldKey(testFieldOverrides{}, "Kind"):        {Nullable: true},
+		"golift.io/goty_test.testFieldOverrides.Auth.User": {Type: "`user-${string}`"},
+	}}).Parse(testFieldOverrides{})
+
+	members := map[string]*goty.StructMember{}
+	for _, data := range builder.Values() {
+		for _, member := range data.Members {
+			members[member.Name] = member
+		}
+	}
+
+	tests := []struct {
+		name     string
+		typ      string
+		optional bool
+		nullable bool
+	}{
+		{name: "timeout", typ: "string"},
+		{name: "other", typ: "number"},
+		{name: "comment", typ: "string", optional: true, nullable: true},
+		{name: "Kind", typ: "string", nullable: true},
+	}
+
+	for _, test := range tests {
+		member := members[test.name]
+		if member == nil {
+			t.Errorf("%s: member is missing", test.name)
+			continue
+		}
+
+		if member.Type != test.typ || member.Optional != test.optional || member.Nullable != test.nullable {
+			t.Errorf("%s: wrong member, got: %s optional=%v nullable=%v", test.name, member.Type,
+				member.Optional, member.Nullable)
+		}
+	}
+
+	if _, ok := members["secret"]; ok {
+		t.Error("skipped member was not skipped")
+	}
+
+	if user := members["auth"].Members[0]; user.Type != "`user-${string}`" {
+		t.Errorf("anonymous struct member override was not applied, got: %s", user.Type)
+	}
+
+	output := &strings.Builder{}
+	builder.Values()[0].Print("", output)
+
+	if !strings.Contains(output.String(), "A duration, ie. 1m30s.") {
+		t.Errorf("member comment is missing:\n%s", output)
+	}
+}
+
+func TestGotyTagErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []any{
+		struct {
+			Name string `goty:"optinal"`
+		}{},
+		struct {
+			Name string `goty:"name=x,bogus"`
+		}{},
+		struct {
+			Name string `goty:"format=date"`
+		}{},
+		struct {
+			Auth struct {
+				Name string `goty:"readonly,required"`
+			}
+		}{},
+	}
+
+	for idx, test := range tests {
+		t.Run(strconv.Itoa(idx), func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				if err, _ := recover().(error); !errors.Is(err, goty.ErrTagOption) {
+					t.Errorf("expected a panic with ErrTagOption, got: %v", err)
+				}
+			}()
+
+			goty.NewGoty(nil).Parse(test)
+		})
+	}
+}
+
+type testMemberNames struct {
+	APIKey      string
+	URL         string
+	HostID      string
+	UserName2   string
+	Tagged      string `json:"Tagged"`
+	Old_Name    string //nolint:revive // Underscores split words.
+	CrèmeÉclair string
+	Endpoint    testMemberNamesEndpoint
+	Dessert     éclair
+}
+
+// éclair starts with a multibyte rune, so its interface is Éclair.
+type éclair struct {
+	Crème string
+}
+
+type testMemberNamesEndpoint struct {
+	HostName string
+}
+
+func TestMemberNamers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		namer goty.MemberNamer
+		want  []string
+	}{
+		{name: "default", want: []string{
+			"APIKey", "URL", "HostID", "UserName2", "Tagged", "Old_Name", "CrèmeÉclair", "Endpoint", "Dessert",
+		}},
+		{name: "camel", namer: goty.CamelCaseMembers, want: []string{
+			"apiKey", "url", "hostId", "userName2", "Tagged", "oldName", "crèmeÉclair", "endpoint", "dessert",
+		}},
+		{name: "snake", namer: goty.SnakeCaseMembers, want: []string{
+			"api_key", "url", "host_id", "user_name2", "Tagged", "old_name", "crème_éclair", "endpoint", "dessert",
+		}},
+		{name: "lower", namer: goty.LowerCaseMembers, want: []string{
+			"apikey", "url", "hostid", "username2", "Tagged", "old_name", "crèmeéclair", "endpoint", "dessert",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			slices.Sort(test.want) // tsKeys sorts the names.
+
+			config := &goty.Config{GlobalOverrides: goty.Override{MemberNamer: test.namer}}
+			builder := goty.NewGoty(config).Parse(testMemberNames{})
+			if got := tsKeys(builder.Values(), "TestMemberNames"); !slices.Equal(got, test.want) {
+				t.Errorf("wrong member names\n got: %v\nwant: %v", got, test.want)
+			}
+		})
+	}
+
+	// A type override names the members of that struct only, and the hook gets the parent type.
+	config := &goty.Config{Overrides: goty.Overrides{
+		testMemberNamesEndpoint{}: {MemberNamer: func(parent reflect.Type, field reflect.StructField) string {
+			return parent.Name() + "." + field.Name
+		}},
+	}}
+	builder := goty.NewGoty(config).Parse(testMemberNames{})
+
+	want := []string{"testMemberNamesEndpoint.HostName"}
+	if got := tsKeys(builder.Values(), "TestMemberNamesEndpoint"); !slices.Equal(got, want) {
+		t.Errorf("type override member namer was not used: %v", got)
+	}
+
+	if got := tsKeys(builder.Values(), "TestMemberNames"); !slices.Contains(got, "APIKey") {
+		t.Errorf("type override member namer was used on another type: %v", got)
+	}
+
+	// Names are capitalized by rune, so éclair becomes Éclair.
+	if got := tsKeys(builder.Values(), capitalize("éclair")); !slices.Equal(got, []string{"Crème"}) {
+		t.Errorf("multibyte interface name is wrong: %v", got)
+	}
+}
+
+type (
+	testJSONValue struct{ Secret string }
+	testJSONPtr   struct{ Secret string }
+	testTextValue struct{ Secret string }
+	testTextPtr   struct{ Secret string }
+	// testBoth has both marshalers; json.Marshaler wins.
+	testBoth struct{ Secret string }
+	// testJSONOverride is a json.Marshaler with a type override.
+	testJSONOverride struct{ Secret string }
+)
+
+func (testJSONValue) MarshalJSON() ([]byte, error)    { return []byte(`{}`), nil }
+func (*testJSONPtr) MarshalJSON() ([]byte, error)     { return []byte(`[]`), nil }
+func (testTextValue) MarshalText() ([]byte, error)    { return []byte(`value`), nil }
+func (*testTextPtr) MarshalText() ([]byte, error)     { return []byte(`ptr`), nil }
+func (testBoth) MarshalJSON() ([]byte, error)         { return []byte(`1`), nil }
+func (testBoth) MarshalText() ([]byte, error)         { return []byte(`both`), nil }
+func (testJSONOverride) MarshalJSON() ([]byte, error) { return []byte(`{}`), nil }
+
+type TestMarshalers struct {
+	JSONValue testJSONValue         `json:"jsonValue"`
+	JSONPtr   *testJSONPtr          `json:"jsonPtr"`
+	TextValue testTextValue         `json:"textValue"`
+	TextPtr   testTextPtr           `json:"textPtr"`
+	Both      testBoth              `json:"both"`
+	Texts     []*testTextPtr        `json:"texts"`
+	Keys      map[testTextValue]int `json:"keys"`
+	Override  []testJSONOverride    `json:"override"`
+}
+
+// A type override beats the marshaler, even when the type is nested in a slice.
+func ExampleGoty_Parse_marshalers() {
+	goty := goty.NewGoty(&goty.Config{Overrides: goty.Overrides{testJSONOverride{}: {Type: "Record<string, never>"}}})
+	goty.Parse(TestMarshalers{})
+	goty.Values()[0].Print("", os.Stdout)
+	// Output:
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestMarshalers>
+	//  */
+	// export interface TestMarshalers {
+	//   jsonValue: any;
+	//   jsonPtr: any | null;
+	//   textValue: string;
+	//   textPtr: string;
+	//   both: any;
+	//   texts: string[] | null;
+	//   keys: Record<string, number> | null;
+	//   override: Record<string, never>[] | null;
+	// };
+}
+
+type testMappings struct {
+	When  time.Time   `json:"when"`
+	Count json.Number `json:"count"`
+	Label TestLabel   `json:"label"`
+}
+
+func TestMappings(t *testing.T) {
+	t.Parallel()
+
+	extended := goty.DefaultMappings()
+	extended[TestLabel("")] = "`label-${string}`"
+
+	tests := []struct {
+		name   string
+		config *goty.Config
+		want   map[string]string
+	}{
+		{name: "default", config: &goty.Config{},
+			want: map[string]string{"when": "Date", "count": "number", "label": "string"}},
+		{name: "replaced", config: &goty.Config{Mappings: goty.Mappings{time.Time{}: "string"}},
+			want: map[string]string{"when": "string", "count": "string", "label": "string"}},
+		{name: "disabled", config: &goty.Config{Mappings: goty.Mappings{}},
+			want: map[string]string{"when": "any", "count": "string", "label": "string"}},
+		{name: "extended", config: &goty.Config{Mappings: extended},
+			want: map[string]string{"when": "Date", "count": "number", "label": "`label-${string}`"}},
+		{name: "override", config: &goty.Config{Overrides: goty.Overrides{time.Time{}: {Type: "number"}}},
+			want: map[string]string{"when": "number", "count": "number", "label": "string"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tsTypes(goty.NewGoty(test.config).Parse(testMappings{}).Values()[0])
+			if !maps.Equal(got, test.want) {
+				t.Errorf("wrong member types\n got: %v\nwant: %v", got, test.want)
+			}
+		})
+	}
+}
+
+// tsTypes returns the typescript type for each member of an interface, with | null if it's nullable.
+func tsTypes(data *goty.DataStruct) map[string]string {
+	types := map[string]string{}
+	for _, member := range data.Members {
+		types[member.Name] = member.Type
+		if member.Nullable {
+			types[member.Name] += " | null"
+		}
+	}
+
+	return types
+}
+
+type testTuples struct {
+	Point [2]float64   `json:"point"`
+	Big   [20]int      `json:"big"`
+	Hash  [4]byte      `json:"hash"`
+	Pairs [2][2]string `json:"pairs"`
+}
+
+func TestTuples(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config *goty.Config
+		want   map[string]string
+	}{
+		{name: "default", config: &goty.Config{}, want: map[string]string{
+			"point": "[number, number]", "big": "number[]",
+			"hash": "[number, number, number, number]", "pairs": "[[string, string], [string, string]]",
+		}},
+		{name: "max length", config: &goty.Config{GlobalOverrides: goty.Override{MaxTupleLength: 2}}, want: map[string]string{
+			"point": "[number, number]", "big": "number[]", "hash": "number[]", "pairs": "[[string, string], [string, string]]",
+		}},
+		{name: "disabled", config: &goty.Config{GlobalOverrides: goty.Override{MaxTupleLength: -1}}, want: map[string]string{
+			"point": "number[]", "big": "number[]", "hash": "number[]", "pairs": "string[][]",
+		}},
+		{name: "json v2", config: &goty.Config{Profile: goty.ProfileJSONv2}, want: map[string]string{
+			"point": "[number, number]", "big": "number[]", "hash": "string", "pairs": "[[string, string], [string, string]]",
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tsTypes(goty.NewGoty(test.config).Parse(testTuples{}).Values()[0])
+			if !maps.Equal(got, test.want) {
+				t.Errorf("wrong member types\n got: %v\nwant: %v", got, test.want)
+			}
+		})
+	}
+}
+
+type (
+	TestHostID  string
+	TestPort    uint16
+	TestScalars struct {
+		Host  TestHostID `json:"host"`
+		Port  TestPort   `json:"port"`
+		Ports []TestPort `json:"ports"`
+	}
+)
+
+// testDocs is a documentation handler with type docs by name.
+type testDocs map[string]string
+
+func (t testDocs) Type(typ reflect.Type) string             { return t[typ.Name()] }
+func (t testDocs) Member(typ reflect.Type, n string) string { return t[typ.Name()+"."+n] }
+func (t testDocs) Path(typ reflect.Type, path ...string) string {
+	return t[typ.Name()+"."+strings.Join(path, ".")]
+}
+
+func ExampleScalarAlias() {
+	goty := goty.NewGoty(&goty.Config{
+		Docs:            testDocs{"TestHostID": "TestHostID is a host's unique ID."},
+		GlobalOverrides: goty.Override{ScalarAlias: goty.ScalarAliasPlain},
+		Overrides: goty.Overrides{
+			TestPort(0): {ScalarAlias: goty.ScalarAliasBranded, Comment: "A port can't be mixed up with another number."},
+		},
+	})
+	goty.Parse(TestScalars{})
+	goty.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestScalars>
+	//  */
+	// export interface TestScalars {
+	//   host: TestHostID;
+	//   /**
+	//    * A port can't be mixed up with another number.
+	//    */
+	//   port: TestPort;
+	//   ports: TestPort[] | null;
+	// };
+	//
+	// /**
+	//  * TestHostID is a host's unique ID.
+	//  * @see golang: <golift.io/goty_test.TestHostID>
+	//  */
+	// export type TestHostID = string;
+	//
+	// /**
+	//  * A port can't be mixed up with another number.
+	//  * @see golang: <golift.io/goty_test.TestPort>
+	//  */
+	// export type TestPort = number & { __brand: "TestPort" };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+type (
+	TestItem  struct{ Name string }
+	TestEntry struct{ Size int }
+	TestItems []TestItem
+	TestIndex map[string]*TestEntry
+	TestAll   []TestIndex
+)
+
+// Non-struct roots become type aliases. Predeclared types, like int and error, have no alias.
+func ExampleGoty_Parse_roots() {
+	goty := goty.NewGoty(nil)
+	goty.Parse(TestItems{}, &TestIndex{}, (*TestAll)(nil), 5, (*error)(nil), []TestItem{})
+	goty.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestItems>
+	//  */
+	// export type TestItems = TestItem[];
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestItem>
+	//  */
+	// export interface TestItem {
+	//   Name: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestIndex>
+	//  */
+	// export type TestIndex = Record<string, null | TestEntry>;
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestEntry>
+	//  */
+	// export interface TestEntry {
+	//   Size: number;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestAll>
+	//  */
+	// export type TestAll = TestIndex[];
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+type (
+	TestID         int64
+	testStringOpts struct {
+		Count int      `json:"count,string"`
+		Ptr   *int     `json:"ptr,string"`
+		Flag  bool     `json:"flag,string"`
+		Float float64  `json:"float,string"`
+		Slice []int    `json:"slice,string"`
+		Big   int64    `json:"big"`
+		Plain int      `json:"plain"`
+		Small int32    `json:"small"`
+		ID    TestID   `json:"id"`
+		IDs   []TestID `json:"ids"`
+	}
+)
+
+func TestNumbers(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		config *goty.Config
+		want   map[string]string
+	}{
+		{name: "default", config: &goty.Config{}, want: map[string]string{
+			"count": "string", "ptr": "string | null", "flag": "string", "float": "string", "slice": "number[] | null",
+			"big": "number", "plain": "number", "small": "number", "id": "number", "ids": "number[] | null",
+		}},
+		{name: "json v2", config: &goty.Config{Profile: goty.ProfileJSONv2}, want: map[string]string{
+			"count": "string", "ptr": "string | null", "flag": "boolean", "float": "string", "slice": "number[]",
+			"big": "number", "plain": "number", "small": "number", "id": "number", "ids": "number[]",
+		}},
+		{name: "global bigint", config: &goty.Config{GlobalOverrides: goty.Override{Int64: goty.Int64BigInt}},
+			want: map[string]string{
+				"count": "string", "ptr": "string | null", "flag": "string", "float": "string", "slice": "number[] | null",
+				"big": "bigint", "plain": "number", "small": "number", "id": "bigint", "ids": "bigint[] | null",
+			}},
+		{name: "type string", config: &goty.Config{Overrides: goty.Overrides{TestID(0): {Int64: goty.Int64String}}},
+			want: map[string]string{
+				"count": "string", "ptr": "string | null", "flag": "string", "float": "string", "slice": "number[] | null",
+				"big": "number", "plain": "number", "small": "number", "id": "string", "ids": "string[] | null",
+			}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tsTypes(goty.NewGoty(test.config).Parse(testStringOpts{}).Values()[0])
+			if !maps.Equal(got, test.want) {
+				t.Errorf("wrong member types\n got: %v\nwant: %v", got, test.want)
+			}
+		})
+	}
+}
+
+type (
+	TestFn    func()
+	TestQueue chan string
+)
+
+func TestUnsupportedRoots(t *testing.T) {
+	t.Parallel()
+
+	builder := goty.NewGoty(nil).Parse(TestFn(nil), TestQueue(nil), make(chan int), testBase{})
+	if len(builder.Values()) != 1 || builder.Values()[0].Name != "TestBase" {
+		t.Errorf("unsupported roots were not omitted: %v", builder.Values())
+	}
+
+	want := []string{
+		"goty_test.TestFn cannot be marshalled, omitted",
+		"goty_test.TestQueue cannot be marshalled, omitted",
+		"chan int cannot be marshalled, omitted",
+	}
+	if !slices.Equal(builder.Warnings(), want) {
+		t.Errorf("wrong warnings\n got: %q\nwant: %q", builder.Warnings(), want)
+	}
+
+	config := &goty.Config{GlobalOverrides: goty.Override{Unsupported: goty.UnsupportedNever}}
+	if values := goty.NewGoty(config).Parse(TestFn(nil)).Values(); len(values) != 1 || values[0].Alias != "never" {
+		t.Errorf("expected a never alias, got: %v", values)
+	}
+
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, goty.ErrUnsupported) {
+			t.Errorf("expected a panic with ErrUnsupported, got: %v", err)
+		}
+	}()
+
+	config = &goty.Config{Overrides: goty.Overrides{TestQueue(nil): {Unsupported: goty.UnsupportedFail}}}
+	goty.NewGoty(config).Parse(TestFn(nil), TestQueue(nil))
+}
@@ -4,6 +4,7 @@ package goty
 import (
 	"reflect"
 	"slices"
+	"strings"
 
 	"golift.io/goty/gotyface"
 )
@@ -11,6 +12,10 @@ import (
 const (
 	// DefaultTag is the tag name used to find struct member names.
 	DefaultTag = "json"
+	// DefaultGotyTag is the tag name used to find goty's own options, ie. `goty:"groups=admin"`.
+	DefaultGotyTag = "goty"
+	// DefaultMaxTupleLength is the longest go array that becomes a typescript tuple.
+	DefaultMaxTupleLength = 16
 )
 
 // UsePkgName is the behavior for the package name prefix being appended to the interface name.
@@ -31,6 +36,116 @@ const (
 	UsePkgNameAlways
 )
 
+// ScalarAlias is the behavior for named go scalar types, like `type HostID string`.
+type ScalarAlias uint8
+
+const (
+	// ScalarAliasNever turns named scalar types into their basic typescript type, ie. string.
+	// This is the default behavior.
+	ScalarAliasNever ScalarAlias = iota
+	// ScalarAliasPlain turns named scalar types into typescript type aliases.
+	// ie. `export type HostID = string;`.
+	ScalarAliasPlain
+	// ScalarAliasBranded turns named scalar types into branded typescript type aliases.
+	// ie. `export type HostID = string & { __brand: "HostID" };`.
+	// Branded types cannot be assigned from other strings without a type assertion.
+	ScalarAliasBranded
+)
+
+// Embed is the behavior for embedded structs.
+type Embed uint8
+
+const (
+	// EmbedAuto extends embedded structs, unless one of their members is shadowed by
+	// the parent struct, or conflicts with another embedded struct. Those are flattened.
+	// This always produces valid typescript. This is the default behavior.
+	EmbedAuto Embed = iota
+	// EmbedExtends always extends embedded structs: `interface A extends B`.
+	// This produces invalid typescript when a shadowed member has a different type.
+	EmbedExtends
+	// EmbedFlatten adds the visible members from embedded structs directly into the parent interface.
+	EmbedFlatten
+	// EmbedOmit extends embedded structs, and omits the shadowed members: `interface A extends Omit<B, "x">`.
+	EmbedOmit
+)
+
+// Unsupported is the behavior for members the marshaller cannot encode, like channels and funcs.
+type Unsupported uint8
+
+const (
+	// UnsupportedOmit leaves unsupported members out of the interface, and saves a warning.
+	// This is the default behavior.
+	UnsupportedOmit Unsupported = iota
+	// UnsupportedNever marks unsupported members as never present: `member?: never;`.
+	UnsupportedNever
+	// UnsupportedFail panics with ErrUnsupported when an unsupported member is found.
+	UnsupportedFail
+)
+
+// Int64 is the typescript type for int64 and uint64.
+// Javascript numbers lose precision above 2^53, so large IDs may need a different type.
+type Int64 uint8
+
+const (
+	// Int64Number turns 64-bit integers into typescript numbers. This is the default.
+	Int64Number Int64 = iota
+	// Int64BigInt turns 64-bit integers into typescript bigints.
+	// Your json parser must be able to produce bigints for this to be accurate.
+	Int64BigInt
+	// Int64String turns 64-bit integers into typescript strings.
+	// Use this when your go code encodes them as strings, ie. with a custom marshaller.
+	Int64String
+)
+
+// Profile is the marshaller that encodes the go structs. It changes how struct tags are read,
+// and which values may be null or missing.
+type Profile uint8
+
+const (
+	// ProfileJSON follows the rules of encoding/json. This is the default profile.
+	ProfileJSON Profile = iota
+	// ProfileJSONv2 follows the rules of encoding/json/v2 and github.com/go-json-experiment/json.
+	//   - Nil slices and maps encode as empty arrays and objects, so they are never null.
+	//   - Byte arrays encode as base64 strings, like byte slices.
+	//   - omitempty leaves out values that encode as null, "", {} or [].
+	//   - The string option only quotes numbers.
+	//   - Names may be single-quoted, ie. `json:"'a,b'"`.
+	//   - The inline (or embed) option flattens a struct field into its parent.
+	//     On a map or jsontext.Value the option, or the unknown option, becomes an index signature.
+	//   - The format option changes the type of times, durations, bytes, floats, slices and maps.
+	//   - The case option only affects unmarshalling, so it's ignored.
+	ProfileJSONv2
+	// ProfileYAML follows the rules of gopkg.in/yaml.v3. The default tag is "yaml".
+	//   - Untagged fields are lowercased, ie. UserName becomes username.
+	//   - Embedded structs are members, unless they have the inline option.
+	//     The inline option on a map becomes an index signature.
+	//   - omitempty leaves out any zero value, including empty structs.
+	//   - Nil slices and maps are [] and {}, and byte slices are arrays of numbers.
+	//   - Durations are strings, and MarshalYAML methods produce anything.
+	ProfileYAML
+	// ProfileTOML follows the rules of github.com/BurntSushi/toml. The default tag is "toml".
+	//   - TOML has no null. Nil pointers, slices, maps and interfaces are left out instead.
+	//   - omitempty leaves out any zero value, including empty structs.
+	//   - Byte slices are arrays of numbers, and map keys must be strings.
+	//   - Durations are strings, and MarshalTOML methods produce anything.
+	ProfileTOML
+	// ProfileMapstructure follows the rules of github.com/go-viper/mapstructure. The default tag is "mapstructure".
+	//   - Embedded structs are members, unless they have the squash option.
+	//   - The remain option on a map becomes an index signature.
+	//   - omitempty leaves out any zero value, including empty structs.
+	//   - Values are copied, not marshalled, so marshaller methods are ignored.
+	ProfileMapstructure
+	// ProfileXML follows the rules of encoding/xml. The default tag is "xml".
+	//   - XML has no null. Nil pointers, slices and interfaces are left out instead.
+	//   - XMLName fields are dropped. Attributes are members like any other.
+	//   - Nested paths, ie. `xml:"a>b>c"`, become nested objects.
+	//   - chardata members are named by Config.XMLCharData, and comment members are named #comment.
+	//     Empty comments are not written, so comments are optional.
+	//     innerxml members have the go field name. The any option becomes an index signature.
+	//   - Maps cannot be marshalled, and MarshalXML methods produce anything.
+	ProfileXML
+)
+
 // Config is the input config for the builder.
 type Config struct {
 	// DocHandler is the handler for go/doc comments. Comments are off by default.
@@ -39,13 +154,62 @@ type Config struct {
 	// Overrides is a map of go types to their typescript type and name.
 	// These override the global overrides.
 	Overrides Overrides `json:"overrides" toml:"overrides" xml:"overrides" yaml:"overrides"`
+	// FieldOverrides is a map of struct fields to their typescript member overrides.
+	// These are applied before the type-specific and global overrides. See FieldKey.
+	FieldOverrides FieldOverrides `json:"fieldOverrides" toml:"field_overrides" xml:"field-overrides" yaml:"fieldOverrides"` //nolint:lll
 	// GlobalOverrides are applied to all structs unless a type-specific override exists.
 	GlobalOverrides Override `json:"globalOverrides" toml:"global_overrides" xml:"global-override" yaml:"globalOverrides"`
+	// Mappings is the registry of known go types and their typescript types.
+	// If this is nil, DefaultMappings() is used. Set it to an empty map to disable mappings.
+	Mappings Mappings `json:"mappings" toml:"mappings" xml:"mappings" yaml:"mappings"`
+	// Samples enables sample-driven parsing. Populated values passed into Parse() are inspected,
+	// and the concrete types inside interface members, maps and slices are used instead of any.
+	// When samples disagree, the result is a union. Values that are a reflect.Type are not samples.
+	Samples bool `json:"samples" toml:"samples" xml:"samples" yaml:"samples"`
+	// Profile is the marshaller that encodes your go structs. Default is encoding/json.
+	Profile Profile `json:"profile" toml:"profile" xml:"profile" yaml:"profile"`
+	// Views parse every struct once for each view, with the view's tag and profile.
+	// Interfaces that are different in a view get the view's suffix, ie. ConfigJSON and ConfigTOML.
+	// Interfaces that are the same in every view are only printed once. Profile is not used with views.
+	// Views may also leave out fields by group, see View.Groups.
+	Views []View `json:"views" toml:"views" xml:"views" yaml:"views"`
+	// GotyTag is the struct tag with goty's own options, ie. `goty:"groups=admin,internal"`. Default is "goty".
+	GotyTag string `json:"gotyTag" toml:"goty_tag" xml:"goty-tag" yaml:"gotyTag"`
+	// XMLCharData is the member name for `xml:",chardata"` fields in the XML profile. Default is "#text".
+	XMLCharData string `json:"xmlCharData" toml:"xml_char_data" xml:"xml-char-data" yaml:"xmlCharData"`
+	// mappings is the Mappings map with reflect types for keys.
+	mappings map[reflect.Type]string
 }
 
 // Overrides is a map of go types to their typescript override values.
 type Overrides map[any]Override
 
+// FieldOverrides is a map of struct fields to their member override values.
+// The keys are the package path, the struct name and the field name, ie. "golift.io/goty.Config.Profile".
+// Members of anonymous structs use the path from the named struct, ie. "pkg.Type.Auth.Username".
+type FieldOverrides map[string]FieldOverride
+
+// FieldOverride contains overrides for one struct member. Unlike Override, these do not change the member's type
+// anywhere else it's used. ie. one time.Duration field can be a string without changing every time.Duration.
+// These may also be in the goty struct tag, ie. `goty:"type=string,optional,readonly,name=hostId,skip"`.
+// The options in FieldOverrides are applied on top of the struct tag options.
+type FieldOverride struct {
+	// Typescript type. ie. string, number, boolean, etc.
+	Type string `json:"type" toml:"type" xml:"type" yaml:"type"`
+	// Name is the member name. This replaces the name from the struct tag.
+	Name string `json:"name" toml:"name" xml:"name" yaml:"name"`
+	// Comment is a comment to add to the member.
+	Comment string `json:"comment" toml:"comment" xml:"comment" yaml:"comment"`
+	// Setting optional to true will add a question mark to the member name.
+	Optional bool `json:"optional" toml:"optional" xml:"optional" yaml:"optional"`
+	// Setting nullable to true will add | null to the member type.
+	Nullable bool `json:"nullable" toml:"nullable" xml:"nullable" yaml:"nullable"`
+	// Setting readonly to true will add readonly to the member name.
+	Readonly bool `json:"readonly" toml:"readonly" xml:"readonly" yaml:"readonly"`
+	// Setting skip to true leaves the member out of the interface.
+	Skip bool `json:"skip" toml:"skip" xml:"skip" yaml:"skip"`
+}
+
 // Override is a struct that contains overrides for either a specific type or for all types (when global).
 //
 //nolint:lll // Can't break this up I don't think.
@@ -59,17 +223,18 @@ type Override struct {
 	// Typescript interface name. This does not work on field names.
 	// This has no effect when set inside a global override; it's type specific.
 	Name string `json:"name" toml:"name" xml:"name" yaml:"name"`
-	// Tag is the tag name to use for the struct member(s). Default is "json".
+	// Tag is the tag name to use for the struct member(s). Default is the profile's tag, ie. "json".
 	Tag string `json:"tag" toml:"tag" xml:"tag" yaml:"tag"`
 	// Comment is a comment to add to the typescript interface.
 	Comment string `json:"comment" toml:"comment" xml:"comment" yaml:"comment"`
 	// Setting optional to true will add a question mark to the typescript name.
 	// This has no effect when set inside a global override; it's type specific.
 	Optional bool `json:"optional" toml:"optional" xml:"optional" yaml:"optional"`
-	// Setting KeepBadChars to true will keep bad characters in the typescript name.
+	// Setting KeepBadChars to true will keep bad characters in the typescript interface name.
 	// These include pretty much all those characters on the number keys on your keyboard.
+	// Member names are never changed; they are quoted if they are not valid identifiers.
 	KeepBadChars bool `json:"keepBadChars" toml:"keep_bad_chars" xml:"keep-bad-chars" yaml:"keepBadChars"`
-	// Setting KeepUnderscores to true will keep underscores in the typescript name.
+	// Setting KeepUnderscores to true will keep underscores in the typescript interface name.
 	// Unlike other characters, underscores are valid. They are still removed by default.
 	KeepUnderscores bool `json:"keepUnderscores" toml:"keep_underscores" xml:"keep-underscores" yaml:"keepUnderscores"`
 	// Configure the UsePkgName value to control how typescript interface names are generated.
@@ -79,11 +244,45 @@ type Override struct {
 	// Setting NullSlicePointers to true causes the builder to add | null to slices of pointers.
 	// If your pointer slices are nullable, set this to true.
 	NullSlicePointers bool `json:"nullSlicePointers" toml:"null_slice_pointers" xml:"null-slice-pointers" yaml:"nullSlicePointers"`
+	// MaxTupleLength is the longest go array that becomes a typescript tuple, ie. [number, number].
+	// Longer arrays become typescript arrays, ie. number[]. Default is 16. Set to -1 to disable tuples.
+	MaxTupleLength int `json:"maxTupleLength" toml:"max_tuple_length" xml:"max-tuple-length" yaml:"maxTupleLength"`
+	// Instantiated generic go types become generic typescript interfaces, ie. Page<User>.
+	// Set NoGenerics to true to give each instance its own interface instead, ie. PageOfUser.
+	NoGenerics bool `json:"noGenerics" toml:"no_generics" xml:"no-generics" yaml:"noGenerics"`
+	// ScalarAlias controls whether named scalar types, like `type Port uint16`, become type aliases.
+	ScalarAlias ScalarAlias `json:"scalarAlias" toml:"scalar_alias" xml:"scalar-alias" yaml:"scalarAlias"`
+	// Int64 controls the typescript type for int64 and uint64. It does not change int or uint.
+	Int64 Int64 `json:"int64" toml:"int64" xml:"int64" yaml:"int64"`
+	// Embed controls how embedded structs are added to the typescript interface.
+	Embed Embed `json:"embed" toml:"embed" xml:"embed" yaml:"embed"`
+	// Hoist moves anonymous struct members into their own interfaces, instead of inlining them.
+	// The interfaces are named by HoistNamer, and then by Namer like any other interface.
+	Hoist bool `json:"hoist" toml:"hoist" xml:"hoist" yaml:"hoist"`
+	// HoistNamer names the interface for a hoisted anonymous struct. The default joins the path.
+	HoistNamer HoistNamer `json:"-" toml:"-" xml:"-" yaml:"-"`
+	// MemberNamer names the members for untagged struct fields. The default is the profile's rule,
+	// which keeps the go field name, or lowercases it for yaml. See CamelCaseMembers for built-in namers.
+	// In a type-specific override, this names the members of that struct type.
+	MemberNamer MemberNamer `json:"-" toml:"-" xml:"-" yaml:"-"`
+	// Unsupported controls what happens to members the marshaller cannot encode.
+	// Those are channels, funcs, complex numbers, unsafe pointers, and maps with unsupported keys.
+	Unsupported Unsupported `json:"unsupported" toml:"unsupported" xml:"unsupported" yaml:"unsupported"`
 }
 
 // Namer is an interface that allows external interface naming.
 type Namer func(refType reflect.Type, currentName string) string
 
+// MemberNamer is an interface that allows external naming of members for untagged struct fields.
+// The parent is the struct type the field is declared in.
+type MemberNamer func(parent reflect.Type, field reflect.StructField) string
+
+// HoistNamer is an interface that allows external naming of hoisted anonymous structs.
+// The path starts with the go type name of the struct it's in, followed by the go field names
+// that lead to it. ie. [TestWrapper Auth Decoy] becomes TestWrapperAuthDecoy by default.
+// The Namer and view affixes are applied to the result, like any other interface name.
+type HoistNamer func(path []string) string
+
 // NewGoty creates a new Goty instance to build typescript interfaces from go structs.
 // If config is nil, it will be initialized to an empty Override.
 func NewGoty(config *Config) *Goty {
@@ -93,6 +292,9 @@ func NewGoty(config *Config) *Goty {
 		config:      config.setup(),
 		output:      make([]*DataStruct, 0),
 		pkgPaths:    make(map[string]struct{}),
+		generics:    make(map[string]*DataStruct),
+		instances:   make(map[reflect.Type]bool),
+		sampled:     make(map[sample]bool),
 	}
 }
 
@@ -119,6 +321,24 @@ func (g *Goty) Pkgs() []string {
 	return output
 }
 
+// Warnings returns the problems the builder worked around while parsing.
+// This includes interfaces that were renamed because their name is reserved in typescript.
+func (g *Goty) Warnings() []string {
+	return g.warnings
+}
+
+// FieldKey returns the FieldOverrides key for a struct field, ie. "golift.io/goty.Config.Profile".
+// The struct may be a value, a pointer or a reflect.Type. Fields promoted from an embedded struct
+// belong to the embedded struct, so the key has that struct's name.
+func FieldKey(elem any, field string) string {
+	typ := derefType(getType(elem))
+	if found, ok := typ.FieldByName(field); ok {
+		typ = fieldOwner(typ, found.Index)
+	}
+
+	return typ.PkgPath() + "." + typ.Name() + "." + field
+}
+
 func getType(fld any) reflect.Type {
 	switch t := fld.(type) {
 	case reflect.Type:
@@ -134,6 +354,18 @@ func (c *Config) setup() *Config {
 		c = &Config{}
 	}
 
+	if c.XMLCharData == "" {
+		c.XMLCharData = DefaultXMLCharData
+	}
+
+	if c.GotyTag == "" {
+		c.GotyTag = DefaultGotyTag
+	}
+
+	if c.GlobalOverrides.Tag == "" {
+		c.GlobalOverrides.Tag = c.Profile.tag()
+	}
+
 	c.GlobalOverrides.setup()
 	// These are not used in global overrides, make that more obvious.
 	c.GlobalOverrides.Type = ""
@@ -143,6 +375,12 @@ func (c *Config) setup() *Config {
 		c.Docs = gotyface.NoDocs()
 	}
 
+	if c.Mappings == nil {
+		c.Mappings = DefaultMappings()
+	}
+
+	c.mappings = c.Mappings.setup()
+
 	return c
 }
 
@@ -153,6 +391,10 @@ func (o *Override) setup() *Override {
 		o.Tag = DefaultTag // "json"
 	}
 
+	if o.MaxTupleLength == 0 {
+		o.MaxTupleLength = DefaultMaxTupleLength
+	}
+
 	if o.UsePkgName == 0 {
 		o.UsePkgName = UsePkgNameOnConflict // explicit.
 	}
@@ -163,6 +405,12 @@ func (o *Override) setup() *Override {
 		}
 	}
 
+	if o.HoistNamer == nil {
+		o.HoistNamer = func(path []string) string {
+			return strings.Join(path, "")
+		}
+	}
+
 	return o
 }
 
@@ -171,6 +419,14 @@ func (o *Override) setup() *Override {
 func (c *Config) override(typ reflect.Type) *Override {
 	for loop, override := range c.Overrides {
 		if t := getType(loop); t == typ {
+			if override.Tag == "" {
+				override.Tag = c.GlobalOverrides.Tag // The profile's tag.
+			}
+
+			if override.MemberNamer == nil {
+				override.MemberNamer = c.GlobalOverrides.MemberNamer
+			}
+
 			return override.setup()
 		}
 	}
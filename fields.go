@@ -0,0 +1,296 @@
+package goty
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// structField is a struct member that is visible to the encoder.
+// These come out of structFields and follow the same rules as encoding/json.
+type structField struct {
+	// name is the encoded member name.
+	name string
+	// tagged is true if the name came from a struct tag.
+	tagged bool
+	// index is the path to the field through embedded structs. See reflect.Type.FieldByIndex.
+	index []int
+	// field is the go struct field.
+	field reflect.StructField
+	// opts are the struct tag options after the name.
+	opts []string
+	// format is the value of the json v2 format option.
+	format string
+	// fallback is true if this json v2 field holds the members no other field handles.
+	fallback bool
+	// goty are the options from the goty struct tag.
+	goty gotyOptions
+}
+
+// structFields returns the fields of a struct that are visible to the encoder.
+// This follows the rules in encoding/json's typeFields:
+//   - Tagged embedded structs are named members, not embedded.
+//   - Untagged embedded structs have their members promoted, even if the struct is unexported.
+//   - Embedded non-struct types are named members, unless they are unexported.
+//   - Conflicting names keep the shallowest field, or the only tagged field at that depth.
+//     Otherwise all the conflicting fields are dropped.
+func (g *Goty) structFields(typ reflect.Type) []structField {
+	fields := []structField{}
+	next := []structField{{index: nil, field: reflect.StructField{Type: typ}}}
+	// count and nextCount keep track of how many times a struct type was found at a depth.
+	count, nextCount := map[reflect.Type]int{}, map[reflect.Type]int{}
+	visited := map[reflect.Type]bool{}
+
+	for len(next) > 0 {
+		current := next
+		next = nil
+		count, nextCount = nextCount, map[reflect.Type]int{}
+
+		for _, parent := range current {
+			ptyp := derefType(parent.field.Type)
+			if visited[ptyp] {
+				continue
+			}
+
+			visited[ptyp] = true
+
+			for idx := range ptyp.NumField() {
+				field, explore, ok := g.newStructField(ptyp, parent.index, idx)
+				if !ok {
+					continue
+				}
+
+				if !explore {
+					fields = append(fields, field)
+					if count[ptyp] > 1 {
+						// The parent was found twice at this depth. Add this again so it gets dropped.
+						fields = append(fields, field)
+					}
+
+					continue
+				}
+
+				// Embedded struct; look at its members in the next round.
+				embedded := derefType(field.field.Type)
+				if nextCount[embedded]++; nextCount[embedded] == 1 {
+					next = append(next, field)
+				}
+			}
+		}
+	}
+
+	return dominantFields(fields)
+}
+
+// newStructField returns a field of the struct type, and whether it's an embedded struct to explore.
+// The last return value is false if the field is not visible to the encoder.
+func (g *Goty) newStructField(typ reflect.Type, parent []int, idx int) (structField, bool, bool) {
+	field := typ.Field(idx)
+	if field.Anonymous {
+		if !field.IsExported() && derefType(field.Type).Kind() != reflect.Struct {
+			return structField{}, false, false // Embedded unexported non-struct types are ignored.
+		}
+	} else if !field.IsExported() {
+		return structField{}, false, false
+	}
+
+	tag := field.Tag.Get(g.config.override(field.Type).Tag)
+	if tag == "-" {
+		return structField{}, false, false
+	}
+
+	goty := parseGotyTag(field.Tag.Get(g.config.GotyTag))
+	if !g.inGroups(goty.groups) {
+		return structField{}, false, false // Not in this view.
+	}
+
+	name, opts, format := g.splitTag(tag)
+	if !utf8.ValidString(name) || (g.config.Profile == ProfileJSON && !isValidTag(name)) {
+		name = ""
+	}
+
+	output := structField{
+		name:   name,
+		tagged: name != "",
+		index:  append(slices.Clone(parent), idx),
+		field:  field,
+		opts:   opts,
+		format: format,
+		goty:   goty,
+	}
+
+	if !output.tagged {
+		output.name = g.memberName(typ, field)
+	}
+
+	if g.config.Profile == ProfileXML && !g.xmlField(&output) {
+		return structField{}, false, false
+	}
+
+	// Unnamed pointers are followed, like the json package does.
+	elem := field.Type
+	if elem.Name() == "" && elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+
+	explore, fallback := g.config.Profile.flatten(output, elem)
+
+	switch {
+	case fallback:
+		output.name, output.tagged, output.fallback = "", false, true
+	case !explore && field.Anonymous && !field.IsExported() &&
+		(g.config.Profile == ProfileYAML || g.config.Profile == ProfileMapstructure):
+		return structField{}, false, false // Unexported embedded structs that are not flattened.
+	}
+
+	return output, explore, true
+}
+
+// splitTag splits a struct tag into the name, the options, and the json v2 format option value.
+// The json v2 profile allows single-quoted names and format values, so they may contain commas.
+func (g *Goty) splitTag(tag string) (string, []string, string) {
+	if g.config.Profile != ProfileJSONv2 {
+		name, opts, _ := strings.Cut(tag, ",")
+		return name, strings.Split(opts, ","), ""
+	}
+
+	name, rest := cutQuoted(tag)
+	rest = strings.TrimPrefix(rest, ",")
+
+	// The format option is always last, and its value may be quoted.
+	format := ""
+	if idx := strings.Index(","+rest, ",format:"); idx >= 0 {
+		format, _ = cutQuoted(rest[idx+len("format:"):])
+		rest = strings.TrimSuffix(rest[:idx], ",")
+	}
+
+	return name, strings.Split(rest, ","), format
+}
+
+// cutQuoted returns a single-quoted or unquoted value from the start of a tag, and the rest of the tag.
+func cutQuoted(tag string) (string, string) {
+	if !strings.HasPrefix(tag, "'") {
+		value, rest, _ := strings.Cut(tag, ",")
+		return value, "," + rest
+	}
+
+	for idx := 1; idx < len(tag); idx++ {
+		switch tag[idx] {
+		case '\\':
+			idx++
+		case '\'':
+			value, err := strconv.Unquote(`"` + strings.ReplaceAll(tag[1:idx], `\'`, `'`) + `"`)
+			if err != nil {
+				return "", tag[idx+1:]
+			}
+
+			return value, tag[idx+1:]
+		}
+	}
+
+	return "", ""
+}
+
+// isFallback returns true if json v2 can put unknown object members into the type.
+// That's a map with string keys, or a jsontext.Value.
+func isFallback(typ reflect.Type) bool {
+	return (typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String) || isJSONTextValue(typ)
+}
+
+// isJSONTextValue returns true for jsontext.Value from encoding/json/jsontext or
+// github.com/go-json-experiment/json/jsontext. We check the name to avoid the imports.
+func isJSONTextValue(typ reflect.Type) bool {
+	return typ.Name() == "Value" && strings.HasSuffix(typ.PkgPath(), "/jsontext") && typ.Kind() == reflect.Slice
+}
+
+// dominantFields removes fields with conflicting names, and keeps the dominant field.
+// The output is sorted in struct field order.
+func dominantFields(fields []structField) []structField {
+	// Sort by name, then depth, then tagged fields first, then field order.
+	slices.SortStableFunc(fields, func(a, b structField) int {
+		if a.name != b.name {
+			return strings.Compare(a.name, b.name)
+		}
+
+		if len(a.index) != len(b.index) {
+			return len(a.index) - len(b.index)
+		}
+
+		if a.tagged != b.tagged {
+			if a.tagged {
+				return -1
+			}
+
+			return 1
+		}
+
+		return slices.Compare(a.index, b.index)
+	})
+
+	output := fields[:0]
+
+	for start := 0; start < len(fields); {
+		end := start + 1
+		for end < len(fields) && fields[end].name == fields[start].name {
+			end++
+		}
+
+		if dominant, ok := dominantField(fields[start:end]); ok {
+			output = append(output, dominant)
+		}
+
+		start = end
+	}
+
+	slices.SortFunc(output, func(a, b structField) int {
+		return slices.Compare(a.index, b.index)
+	})
+
+	return output
+}
+
+// dominantField returns the field that wins a name conflict.
+// The fields are sorted; the first field is the shallowest, and tagged if any at that depth are.
+// If there is more than one field at that depth, and more than one tagged, there is no winner.
+func dominantField(fields []structField) (structField, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return structField{}, false
+	}
+
+	return fields[0], true
+}
+
+// hasOpt returns true if the struct tag had the option.
+func (f *structField) hasOpt(opt string) bool {
+	return slices.Contains(f.opts, opt)
+}
+
+// isValidTag returns true if the json package accepts the name in a struct tag.
+func isValidTag(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, char := range name {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:;<=>?@[]^_{|}~ ", char):
+			// Backslash and quote chars are reserved, but otherwise any punctuation chars are allowed.
+		case !unicode.IsLetter(char) && !unicode.IsDigit(char):
+			return false
+		}
+	}
+
+	return true
+}
+
+// derefType returns the type a pointer points to, or the type if it's not a pointer.
+func derefType(typ reflect.Type) reflect.Type {
+	if typ.Kind() == reflect.Ptr {
+		return typ.Elem()
+	}
+
+	return typ
+}
@@ -0,0 +1,295 @@
+package goty
+
+import (
+	"bytes"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Go does not expose type parameters through reflection. All we get is an instantiated
+// type with a name like `Page[golift.io/x.User]`. We recover the type arguments by
+// finding named types in the struct's fields that match the names in the brackets.
+// A type argument that is used once can only come from the type parameter. One used twice,
+// like Page[User] with an Owner User field, could be either, so that instance is not generic.
+// When that's not reliable, the instantiated type gets a readable name, ie. PageOfUser.
+
+// parseGeneric turns an instantiated generic struct into a generic typescript interface.
+// It returns a reference to the interface, ie. Page<User>, or nil if the type
+// arguments cannot be recovered, or if this instance does not fit the interface.
+func (g *Goty) parseGeneric(elem reflect.Type) *DataStruct {
+	base, args, ok := splitGeneric(elem.Name())
+	if !ok || g.config.override(elem).NoGenerics {
+		return nil
+	}
+
+	types := findTypeArgs(elem, args)
+	if types == nil {
+		return nil
+	}
+
+	goName := elem.PkgPath() + "." + base
+	def := g.generics[goName]
+
+	if !g.instances[elem] {
+		if def == nil {
+			def = g.genericDef(elem, base, types)
+		} else if !g.genericFits(def, elem, types) {
+			return nil
+		}
+	}
+
+	// Build the reference with the current type arguments, so nested generics use ours.
+	params := make([]string, len(types))
+	for idx, typ := range types {
+		params[idx], _ = g.parseMember(def, typ, &StructMember{})
+	}
+
+	return &DataStruct{
+		Name:   def.Name + "<" + strings.Join(params, ", ") + ">",
+		Type:   elem,
+		GoName: goName,
+		doc:    def.doc,
+		ovr:    def.ovr,
+	}
+}
+
+// genericDef creates the generic typescript interface from the first instance we find.
+func (g *Goty) genericDef(elem reflect.Type, base string, types []reflect.Type) *DataStruct {
+	def := &DataStruct{
+		Name:       g.makeStructName(elem, base),
+		Type:       elem,
+		GoName:     elem.PkgPath() + "." + base,
+		Members:    make([]*StructMember, 0),
+		TypeParams: typeParams(len(types)),
+		doc:        g.config,
+		ovr:        g.config.override(elem),
+	}
+
+	g.generics[def.GoName] = def
+	g.instances[elem] = true
+	g.structNames[def.Name] = true
+	g.output = append(g.output, def)
+	g.pkgPaths[elem.PkgPath()] = struct{}{}
+
+	g.withTypeArgs(types, def.TypeParams, func() {
+		g.addStructMembers(def, elem, reflect.Value{})
+	})
+
+	return def
+}
+
+// genericFits returns true if another instance of a generic type produces the same interface.
+// This protects us from fields that use the same type as a type argument by coincidence.
+func (g *Goty) genericFits(def *DataStruct, elem reflect.Type, types []reflect.Type) bool {
+	tmp := &DataStruct{
+		Name:       def.Name,
+		Type:       def.Type,
+		GoName:     def.GoName,
+		Members:    make([]*StructMember, 0),
+		TypeParams: def.TypeParams,
+		doc:        def.doc,
+		ovr:        def.ovr,
+	}
+
+	g.instances[elem] = true // avoid recursion while we check.
+	g.withTypeArgs(types, def.TypeParams, func() {
+		g.addStructMembers(tmp, elem, reflect.Value{})
+	})
+
+	if tmp.signature() == def.signature() {
+		return true
+	}
+
+	delete(g.instances, elem)
+
+	return false
+}
+
+// withTypeArgs runs a function while the provided types are replaced by type parameters.
+func (g *Goty) withTypeArgs(types []reflect.Type, params []string, run func()) {
+	saved := g.typeArgs
+	g.typeArgs = make(map[reflect.Type]string, len(types))
+
+	for idx, typ := range types {
+		g.typeArgs[typ] = params[idx]
+	}
+
+	run()
+
+	g.typeArgs = saved
+}
+
+// signature returns the printed interface. Used to compare two interfaces.
+func (s *DataStruct) signature() string {
+	var buf bytes.Buffer
+
+	s.Print("", &buf)
+
+	return buf.String()
+}
+
+// typeParams returns a list of type parameter names: T, or T1, T2, etc.
+func typeParams(count int) []string {
+	if count == 1 {
+		return []string{"T"}
+	}
+
+	params := make([]string, count)
+	for idx := range params {
+		params[idx] = "T" + strconv.Itoa(idx+1)
+	}
+
+	return params
+}
+
+// splitGeneric splits a generic type name into its base name and type arguments.
+// ie. `Pair[int,golift.io/x.User]` returns `Pair` and `int`, `golift.io/x.User`.
+func splitGeneric(name string) (string, []string, bool) {
+	start := strings.IndexByte(name, '[')
+	if start < 1 || !strings.HasSuffix(name, "]") {
+		return name, nil, false
+	}
+
+	args := []string{}
+	depth, last := 0, start+1
+
+	for idx := start + 1; idx < len(name)-1; idx++ {
+		switch name[idx] {
+		case '[', '(', '{':
+			depth++
+		case ']', ')', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				args = append(args, name[last:idx])
+				last = idx + 1
+			}
+		}
+	}
+
+	return name[:start], append(args, name[last:len(name)-1]), true
+}
+
+// findTypeArgs finds the reflect types for type argument names by looking through the struct.
+// Only named types (or pointers to them) from a package are trusted.
+// Returns nil if any are missing, if the same type is used twice, or if a type is in more than one field.
+func findTypeArgs(elem reflect.Type, args []string) []reflect.Type {
+	found := make(map[string]reflect.Type)
+	uses := make(map[reflect.Type]int)
+	collectTypes(elem, found, uses)
+
+	types := make([]reflect.Type, len(args))
+
+	for idx, arg := range args {
+		name := strings.TrimPrefix(arg, "*")
+
+		typ, ok := found[name]
+		if !ok || uses[typ] > 1 {
+			return nil // A field may use the type argument by coincidence.
+		}
+
+		if name != arg {
+			typ = reflect.PointerTo(typ)
+		}
+
+		if slices.Contains(types, typ) {
+			return nil // Pair[User, User] cannot tell its parameters apart.
+		}
+
+		types[idx] = typ
+	}
+
+	return types
+}
+
+// collectTypes walks a type and saves every named type with a package path that it finds.
+// uses counts how many times each type is found. Types inside a type are only walked once.
+func collectTypes(typ reflect.Type, found map[string]reflect.Type, uses map[reflect.Type]int) {
+	if uses[typ]++; uses[typ] > 1 {
+		return
+	}
+
+	if typ.Name() != "" && typ.PkgPath() != "" {
+		found[typ.PkgPath()+"."+typ.Name()] = typ
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		collectTypes(typ.Elem(), found, uses)
+	case reflect.Map:
+		collectTypes(typ.Key(), found, uses)
+		collectTypes(typ.Elem(), found, uses)
+	case reflect.Struct:
+		// Only look inside anonymous structs, generic structs, and the struct we started with.
+		if len(uses) > 1 && typ.Name() != "" && !strings.Contains(typ.Name(), "[") {
+			return
+		}
+
+		for idx := range typ.NumField() {
+			collectTypes(typ.Field(idx).Type, found, uses)
+		}
+	default:
+	}
+}
+
+// genericName returns a readable name for an instantiated generic type, ie. PageOfUser.
+// Non-generic names are returned unchanged.
+func genericName(name string) string {
+	base, args, ok := splitGeneric(name)
+	if !ok {
+		return name
+	}
+
+	for idx, arg := range args {
+		args[idx] = argName(arg)
+	}
+
+	return base + "Of" + strings.Join(args, "And")
+}
+
+// argName returns a readable name for a single type argument.
+func argName(arg string) string {
+	switch {
+	case strings.HasPrefix(arg, "*"):
+		return argName(arg[1:])
+	case strings.HasPrefix(arg, "[]"):
+		return argName(arg[2:]) + "List"
+	case strings.HasPrefix(arg, "map["):
+		end := closeBracket(arg, len("map"))
+		return "MapOf" + argName(arg[len("map["):end]) + "To" + argName(arg[end+1:])
+	}
+
+	if base, _, ok := splitGeneric(arg); ok {
+		if dot := strings.LastIndexByte(base, '.'); dot >= 0 {
+			arg = arg[dot+1:] // strip the package path.
+		}
+
+		return genericName(arg)
+	}
+
+	if dot := strings.LastIndexByte(arg, '.'); dot >= 0 {
+		arg = arg[dot+1:]
+	}
+
+	return capitalizeFirstLetter(arg)
+}
+
+// closeBracket returns the index of the bracket that closes the one at start.
+func closeBracket(name string, start int) int {
+	depth := 0
+
+	for idx := start; idx < len(name); idx++ {
+		switch name[idx] {
+		case '[':
+			depth++
+		case ']':
+			if depth--; depth == 0 {
+				return idx
+			}
+		}
+	}
+
+	return len(name) - 1
+}
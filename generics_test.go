@@ -0,0 +1,136 @@
+package goty_test
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"golift.io/goty"
+)
+
+type TestPage[T any] struct {
+	Items []T `json:"items"`
+	Total int `json:"total"`
+}
+
+type TestUser struct {
+	Name string `json:"name"`
+}
+
+type TestOrder struct {
+	ID int `json:"id"`
+}
+
+type TestPages struct {
+	Users  TestPage[TestUser]  `json:"users"`
+	Orders TestPage[TestOrder] `json:"orders"`
+	Counts TestPage[int]       `json:"counts"`
+}
+
+func ExampleGoty_Parse_generics() {
+	goty := goty.NewGoty(nil)
+	goty.Parse(TestPages{})
+	goty.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestPages>
+	//  */
+	// export interface TestPages {
+	//   users: TestPage<TestUser>;
+	//   orders: TestPage<TestOrder>;
+	//   counts: TestPageOfInt;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestPage>
+	//  */
+	// export interface TestPage<T> {
+	//   items: T[] | null;
+	//   total: number;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestUser>
+	//  */
+	// export interface TestUser {
+	//   name: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestOrder>
+	//  */
+	// export interface TestOrder {
+	//   id: number;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestPage[int]>
+	//  */
+	// export interface TestPageOfInt {
+	//   items: number[] | null;
+	//   total: number;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+type TestOwned[T any] struct {
+	Items []T      `json:"items"`
+	Owner TestUser `json:"owner"`
+}
+
+// TestGenericsOrder makes sure a field that uses a type argument by coincidence does not become T,
+// and that the output does not depend on which instance is parsed first.
+func TestGenericsOrder(t *testing.T) {
+	t.Parallel()
+
+	var first map[string]string
+
+	want := map[string]string{
+		"TestOwned<T>": "owner: TestUser;", "TestOwnedOfTestUser": "owner: TestUser;", "TestUser": "name: string;",
+	}
+
+	for _, elems := range [][]any{
+		{TestOwned[TestUser]{}, TestOwned[TestOrder]{}},
+		{TestOwned[TestOrder]{}, TestOwned[TestUser]{}},
+	} {
+		got := map[string]string{}
+
+		for _, data := range goty.NewGoty(nil).Parse(elems...).Values() {
+			output := &strings.Builder{}
+			data.Print("", output)
+			got[data.Name+typeParams(data)] = output.String()
+		}
+
+		for name, member := range want {
+			if !strings.Contains(got[name], member) {
+				t.Errorf("%T first: interface %s is missing %q:\n%v", elems[0], name, member, got)
+			}
+		}
+
+		if len(got) != len(want)+1 { // TestOrder.
+			t.Errorf("%T first: wrong interfaces: %v", elems[0], slices.Collect(maps.Keys(got)))
+		}
+
+		if first == nil {
+			first = got
+		} else if !maps.Equal(first, got) {
+			t.Errorf("the output depends on the parse order:\n%v\n%v", first, got)
+		}
+	}
+}
+
+// typeParams returns the type parameters of a generic interface, ie. <T>.
+func typeParams(data *goty.DataStruct) string {
+	if len(data.TypeParams) == 0 {
+		return ""
+	}
+
+	return "<" + strings.Join(data.TypeParams, ", ") + ">"
+}
@@ -99,8 +99,14 @@ func (d *Docs) Type(typ reflect.Type) string {
 
 // Member retrieves documentation for a struct member using the handler's index.
 func (d *Docs) Member(parent reflect.Type, name string) string {
+	return d.Path(parent, name)
+}
+
+// Path retrieves documentation for a member of an anonymous struct using the handler's index.
+// The path is the go field names from the parent type, ie. Auth, Username.
+func (d *Docs) Path(parent reflect.Type, path ...string) string {
 	doct := d.findDoc(parent)
-	if doct == nil {
+	if doct == nil || len(path) == 0 {
 		return ""
 	}
 
@@ -114,24 +120,64 @@ func (d *Docs) Member(parent reflect.Type, name string) string {
 		return ""
 	}
 
+	var fields []*ast.Field
+
 	switch typ := tspec.Type.(type) {
 	case *ast.InterfaceType:
-		return findFieldName(typ.Methods.List, name)
+		fields = typ.Methods.List
 	case *ast.StructType:
-		return findFieldName(typ.Fields.List, name)
+		fields = typ.Fields.List
 	default:
 		return ""
 	}
+
+	for idx, name := range path {
+		field := findField(fields, name)
+		if field == nil {
+			return ""
+		}
+
+		if idx == len(path)-1 {
+			return strings.TrimSpace(field.Doc.Text())
+		}
+
+		anon := anonStruct(field.Type)
+		if anon == nil {
+			return ""
+		}
+
+		fields = anon.Fields.List
+	}
+
+	return ""
 }
 
-func findFieldName(fields []*ast.Field, name string) string {
+func findField(fields []*ast.Field, name string) *ast.Field {
 	for _, dm := range fields {
-		if len(dm.Names) > 0 && dm.Names[0].Name == name {
-			return strings.TrimSpace(dm.Doc.Text())
+		for _, ident := range dm.Names {
+			if ident.Name == name {
+				return dm
+			}
 		}
 	}
 
-	return ""
+	return nil
+}
+
+// anonStruct returns the anonymous struct in a field type, including pointers, slices and maps of one.
+func anonStruct(expr ast.Expr) *ast.StructType {
+	switch typ := expr.(type) {
+	case *ast.StructType:
+		return typ
+	case *ast.StarExpr:
+		return anonStruct(typ.X)
+	case *ast.ArrayType:
+		return anonStruct(typ.Elt)
+	case *ast.MapType:
+		return anonStruct(typ.Value)
+	default:
+		return nil
+	}
 }
 
 func (d *Docs) findDoc(typ reflect.Type) *doc.Type {
@@ -140,8 +186,11 @@ func (d *Docs) findDoc(typ reflect.Type) *doc.Type {
 		return nil
 	}
 
+	// Instantiated generic types have their type arguments in the name; remove them.
+	name, _, _ := strings.Cut(typ.Name(), "[")
+
 	for _, doct := range pkg.Types {
-		if doct.Name == typ.Name() {
+		if doct.Name == name {
 			return doct
 		}
 	}
@@ -149,5 +198,8 @@ func (d *Docs) findDoc(typ reflect.Type) *doc.Type {
 	return nil
 }
 
-// Validate the interface implementation.
-var _ gotyface.Docs = &Docs{}
+// Validate the interface implementations.
+var (
+	_ gotyface.Docs     = &Docs{}
+	_ gotyface.PathDocs = &Docs{}
+)
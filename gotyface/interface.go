@@ -13,3 +13,11 @@ type Docs interface {
 	// Member retrieves documentation for a struct or interface member.
 	Member(parent reflect.Type, name string) string
 }
+
+// PathDocs is an optional interface for a Docs handler.
+// Members of anonymous structs have no parent type to look up, so they are found by a path.
+type PathDocs interface {
+	// Path retrieves documentation for a member through the anonymous struct fields that lead to it.
+	// The parent is the named type, and the path is go field names, ie. Auth, Username.
+	Path(parent reflect.Type, path ...string) string
+}
@@ -0,0 +1,53 @@
+package goty
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/netip"
+	"reflect"
+	"time"
+)
+
+// Mappings is a map of go types to the typescript type they are always converted to.
+// Keys may be a value of the type, or a reflect.Type; same as Overrides.
+// Mapped types are never parsed, so they do not produce interfaces.
+// A type-specific Override with a Type set takes precedence over a mapping.
+type Mappings map[any]string
+
+// DefaultMappings returns a new copy of the built-in standard library type mappings.
+// Use this to list the defaults, or to extend them before passing them into Config.
+// These are chosen to match what encoding/json produces. That's why url.URL and the
+// sql.Null* types are missing: they do not marshal themselves and become objects.
+func DefaultMappings() Mappings {
+	return Mappings{
+		reflect.TypeFor[time.Time]():       "Date",
+		reflect.TypeFor[time.Duration]():   "number",
+		reflect.TypeFor[net.IP]():          "string",
+		reflect.TypeFor[netip.Addr]():      "string",
+		reflect.TypeFor[netip.AddrPort]():  "string",
+		reflect.TypeFor[netip.Prefix]():    "string",
+		reflect.TypeFor[big.Int]():         "number",
+		reflect.TypeFor[big.Float]():       "string",
+		reflect.TypeFor[big.Rat]():         "string",
+		reflect.TypeFor[json.RawMessage](): "any",
+		reflect.TypeFor[json.Number]():     "number",
+		reflect.TypeFor[error]():           "any",
+	}
+}
+
+// mapping returns the typescript type for a go type from the mapping registry.
+func (c *Config) mapping(typ reflect.Type) (string, bool) {
+	name, ok := c.mappings[typ]
+	return name, ok
+}
+
+// setup converts the mapping keys to reflect types so they can be looked up quickly.
+func (m Mappings) setup() map[reflect.Type]string {
+	output := make(map[reflect.Type]string, len(m))
+	for key, val := range m {
+		output[getType(key)] = val
+	}
+
+	return output
+}
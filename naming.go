@@ -0,0 +1,72 @@
+package goty
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// memberName returns the member name for an untagged struct field.
+// The struct type's override may have a member namer; otherwise the profile decides.
+func (g *Goty) memberName(parent reflect.Type, field reflect.StructField) string {
+	if namer := g.config.override(parent).MemberNamer; namer != nil {
+		return namer(parent, field)
+	}
+
+	return g.config.Profile.fieldName(field)
+}
+
+// CamelCaseMembers is a MemberNamer that names members in camelCase, ie. APIKey becomes apiKey.
+func CamelCaseMembers(_ reflect.Type, field reflect.StructField) string {
+	words := splitWords(field.Name)
+	for idx, word := range words {
+		words[idx] = strings.ToLower(word)
+		if idx > 0 {
+			words[idx] = capitalizeFirstLetter(words[idx])
+		}
+	}
+
+	return strings.Join(words, "")
+}
+
+// SnakeCaseMembers is a MemberNamer that names members in snake_case, ie. APIKey becomes api_key.
+func SnakeCaseMembers(_ reflect.Type, field reflect.StructField) string {
+	return strings.ToLower(strings.Join(splitWords(field.Name), "_"))
+}
+
+// LowerCaseMembers is a MemberNamer that names members in lowercase, ie. APIKey becomes apikey.
+// This is what the yaml profile does by default.
+func LowerCaseMembers(_ reflect.Type, field reflect.StructField) string {
+	return strings.ToLower(field.Name)
+}
+
+// splitWords splits a go name into words. Acronyms are one word, ie. APIKey is API and Key.
+// Underscores also split words, and are removed.
+func splitWords(name string) []string {
+	runes := []rune(name)
+	words := []string{}
+	start := 0
+
+	for idx := range runes {
+		switch {
+		case runes[idx] == '_':
+			if idx > start {
+				words = append(words, string(runes[start:idx]))
+			}
+
+			start = idx + 1
+		case idx == start || !unicode.IsUpper(runes[idx]):
+		case !unicode.IsUpper(runes[idx-1]),
+			idx+1 < len(runes) && unicode.IsLower(runes[idx+1]):
+			// A new word after a lowercase letter or digit, or the last letter of an acronym starts one.
+			words = append(words, string(runes[start:idx]))
+			start = idx
+		}
+	}
+
+	if start < len(runes) {
+		words = append(words, string(runes[start:]))
+	}
+
+	return words
+}
@@ -5,7 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"slices"
 	"strings"
+
+	"golift.io/goty/gotyface"
 )
 
 // Header is printed before anything else.
@@ -46,7 +50,7 @@ func (g *Goty) Write(fileName string, overwrite bool) error {
 // Print a struct as a typescript interface to an io.Writer.
 func (s *DataStruct) Print(indent string, output io.Writer) {
 	golangRef := "\n * @see golang: <" + s.GoName + ">"
-	doc := formatDocs(false, indent, s.doc.Type(s.Type), s.ovr.Comment)
+	doc := formatDocs(false, indent, s.typeDoc(), s.ovr.Comment)
 	fmt.Fprintln(output, `/**`+doc+golangRef+"\n"+` */`)
 
 	if len(s.Elements) > 0 {
@@ -59,11 +63,21 @@ func (s *DataStruct) Print(indent string, output io.Writer) {
 		exported = ""
 	}
 
+	if s.Alias != "" {
+		fmt.Fprintln(output, indent+exported+`type `+s.Name+` = `+s.Alias+";\n")
+		return
+	}
+
+	name := s.Name
+	if len(s.TypeParams) > 0 {
+		name += "<" + strings.Join(s.TypeParams, ", ") + ">"
+	}
+
 	if len(s.Extends) > 0 {
 		fmt.Fprintf(output, indent+exported+`interface %s extends %s {`,
-			s.Name, strings.Join(s.Extends, `, `))
+			name, strings.Join(s.Extends, `, `))
 	} else {
-		fmt.Fprint(output, indent+exported+`interface `+s.Name+` {`)
+		fmt.Fprint(output, indent+exported+`interface `+name+` {`)
 	}
 
 	if len(s.Members) > 0 {
@@ -84,7 +98,24 @@ func (m *StructMember) Print(indent string, output io.Writer) {
 		optional = "?"
 	}
 
-	doc := formatDocs(true, indent, m.doc.Member(m.parent.Type, m.Member.Name), m.ovr.Comment)
+	null := ""
+	if m.Nullable {
+		null = " | null"
+	}
+
+	doc := formatDocs(true, indent, m.parent.memberDoc(m.doc, m.Member.Name), m.ovr.Comment)
+
+	readonly := ""
+	if m.Readonly {
+		readonly = "readonly "
+	}
+
+	name := m.Name
+	if m.IndexSignature {
+		name = "[key: string]"
+	} else if !isIdentifier(name) {
+		name = quote(name) // Names like api-key are valid json, and must be quoted in typescript.
+	}
 
 	extends := ""
 	if len(m.Extends) > 0 {
@@ -92,21 +123,50 @@ func (m *StructMember) Print(indent string, output io.Writer) {
 	}
 
 	if m.Members == nil {
-		fmt.Fprintln(output, doc+indent+m.Name+optional+`: `+extends+m.Type+`;`)
+		fmt.Fprintln(output, doc+indent+readonly+name+optional+`: `+extends+m.Type+null+`;`)
 		return
 	}
 
-	if optional = ""; m.Optional {
-		optional = "null | "
-	}
-
-	fmt.Fprintln(output, indent+m.Name+`: `+optional+extends+`{`)
+	fmt.Fprintln(output, doc+indent+readonly+name+optional+`: `+extends+`{`)
 
 	for _, m := range m.Members {
 		m.Print(indent+`  `, output)
 	}
 
-	fmt.Fprintln(output, indent+`};`)
+	fmt.Fprintln(output, indent+`}`+null+`;`)
+}
+
+// typeDoc returns the documentation for an interface.
+// A hoisted anonymous struct uses the documentation from the struct field it came from.
+func (s *DataStruct) typeDoc() string {
+	if s.root == nil {
+		return s.doc.Type(s.Type)
+	}
+
+	return pathDoc(s.doc, s.root, s.path[1:]...)
+}
+
+// memberDoc returns the documentation for a member of this struct.
+// Anonymous structs have no type name, so the path from the named type they are in is used.
+func (s *DataStruct) memberDoc(docs gotyface.Docs, name string) string {
+	if s.root == nil {
+		return docs.Member(s.Type, name)
+	}
+
+	return pathDoc(docs, s.root, append(slices.Clone(s.path[1:]), name)...)
+}
+
+// pathDoc returns the documentation for a member of an anonymous struct, if the doc handler can find it.
+func pathDoc(docs gotyface.Docs, parent reflect.Type, path ...string) string {
+	if config, ok := docs.(*Config); ok {
+		docs = config.Docs // The config embeds the handler, but only the handler may find paths.
+	}
+
+	if docs, ok := docs.(gotyface.PathDocs); ok {
+		return docs.Path(parent, path...)
+	}
+
+	return ""
 }
 
 func (g *Goty) print(output io.Writer) {
@@ -1,6 +1,11 @@
 package goty_test
 
 import (
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
 	"time"
 
 	"golift.io/goty"
@@ -78,16 +83,16 @@ func ExampleGoty_Print() {
 	// export interface TestWrapper extends TestEndpoint {
 	//   Profile: TestLevel1;
 	//   Level1: TestLevel1;
-	//   EP?: TestEndpoint;
-	//   Auth: TestEndpoint & TestLevel1 & {
+	//   EP: TestEndpoint | null;
+	//   Auth: Partial<TestEndpoint> & TestLevel1 & {
 	//     username: string;
 	//     password: string;
-	//     Decoy: TestEndpoint & TestLevel1 & {
+	//     Decoy: TestEndpoint & Partial<TestLevel1> & {
 	//       Apple: string;
 	//       Banana: string;
 	//     };
 	//   };
-	//   Config?: Config;
+	//   Config: Config | null;
 	// };
 	//
 	// /**
@@ -110,8 +115,26 @@ func ExampleGoty_Print() {
 	//  * @see golang: <golift.io/goty.Config>
 	//  */
 	// export interface Config {
-	//   overrides?: Record<null | any, Override>;
+	//   fieldOverrides: Record<string, FieldOverride> | null;
 	//   globalOverrides: Override;
+	//   samples: boolean;
+	//   profile: number;
+	//   views: View[] | null;
+	//   gotyTag: string;
+	//   xmlCharData: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty.FieldOverride>
+	//  */
+	// export interface FieldOverride {
+	//   type: string;
+	//   name: string;
+	//   comment: string;
+	//   optional: boolean;
+	//   nullable: boolean;
+	//   readonly: boolean;
+	//   skip: boolean;
 	// };
 	//
 	// /**
@@ -128,9 +151,150 @@ func ExampleGoty_Print() {
 	//   usePkgName: number;
 	//   noExport: boolean;
 	//   nullSlicePointers: boolean;
+	//   maxTupleLength: number;
+	//   noGenerics: boolean;
+	//   scalarAlias: number;
+	//   int64: number;
+	//   embed: number;
+	//   hoist: boolean;
+	//   unsupported: number;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty.View>
+	//  */
+	// export interface View {
+	//   prefix: string;
+	//   suffix: string;
+	//   tag: string;
+	//   profile: number;
+	//   groups: string[] | null;
 	// };
 	//
 	// // Packages parsed:
 	// //   1. golift.io/goty
 	// //   2. golift.io/goty_test
 }
+
+type TestHoist struct {
+	Auth struct {
+		TestEndpoint
+
+		Username string `json:"username"`
+		Decoy    *struct {
+			Apple string `json:"apple"`
+		} `json:"decoy"`
+	} `json:"auth"`
+}
+
+func ExampleGoty_Parse_hoist() {
+	goty := goty.NewGoty(&goty.Config{GlobalOverrides: goty.Override{Hoist: true}})
+	goty.Parse(TestHoist{})
+	goty.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestHoist>
+	//  */
+	// export interface TestHoist {
+	//   auth: TestHoistAuth;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestHoist.Auth>
+	//  */
+	// export interface TestHoistAuth extends TestEndpoint {
+	//   username: string;
+	//   decoy: TestHoistAuthDecoy | null;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestEndpoint>
+	//  */
+	// export interface TestEndpoint {
+	//   url: string;
+	//   apiKey: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestHoist.Auth.Decoy>
+	//  */
+	// export interface TestHoistAuthDecoy {
+	//   apple: string;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+type TestGotyTag struct {
+	HostID  string            `goty:"name=hostId,readonly"                 json:"host_id"`
+	Timeout time.Duration     `goty:"type=string,optional"                 json:"timeout"`
+	Counts  map[string]uint64 `goty:"type=Record<string, number>,nullable" json:"counts"`
+	Secret  string            `goty:"skip"                                 json:"secret"`
+	Auth    struct {
+		Token string `goty:"readonly,type=Uppercase<string>" json:"token"`
+	} `json:"auth"`
+}
+
+func ExampleGoty_Parse_gotyTag() {
+	goty := goty.NewGoty(nil)
+	goty.Parse(TestGotyTag{})
+	goty.Values()[0].Print("", os.Stdout)
+	// Output:
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestGotyTag>
+	//  */
+	// export interface TestGotyTag {
+	//   readonly hostId: string;
+	//   timeout?: string;
+	//   counts: Record<string, number> | null;
+	//   auth: {
+	//     readonly token: Uppercase<string>;
+	//   };
+	// };
+}
+
+// TestPathDocs makes sure members of anonymous structs find their docs through the doc handler.
+func TestPathDocs(t *testing.T) {
+	t.Parallel()
+
+	for _, hoist := range []bool{false, true} {
+		config := &goty.Config{
+			Docs:            testDocs{"TestHoist.Auth.Decoy.Apple": "Apple is a fruit."},
+			GlobalOverrides: goty.Override{Hoist: hoist},
+		}
+		output := &strings.Builder{}
+
+		for _, data := range goty.NewGoty(config).Parse(TestHoist{}).Values() {
+			data.Print("", output)
+		}
+
+		if !strings.Contains(output.String(), "Apple is a fruit.") {
+			t.Errorf("hoist %v: member doc is missing:\n%s", hoist, output)
+		}
+	}
+}
+
+// TestHoistNamer makes sure the Namer is applied once to hoisted interface names.
+func TestHoistNamer(t *testing.T) {
+	t.Parallel()
+
+	config := &goty.Config{GlobalOverrides: goty.Override{
+		Hoist: true,
+		Namer: func(_ reflect.Type, name string) string { return "Noti" + name },
+	}}
+	names := []string{}
+
+	for _, data := range goty.NewGoty(config).Parse(TestHoist{}).Values() {
+		names = append(names, data.Name)
+	}
+
+	want := []string{"NotiTestHoist", "NotiTestHoistAuth", "NotiTestEndpoint", "NotiTestHoistAuthDecoy"}
+	if !slices.Equal(names, want) {
+		t.Errorf("wrong interface names\n got: %v\nwant: %v", names, want)
+	}
+}
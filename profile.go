@@ -0,0 +1,329 @@
+package goty
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// The profile decides which marshaller rules are used. encoding/json is the default.
+// Most of the differences in json v2 are about what may be null or missing,
+// and the format tag option that changes how some types are encoded.
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+// tag returns the struct tag the profile's marshaller reads.
+func (p Profile) tag() string {
+	switch p {
+	case ProfileYAML:
+		return "yaml"
+	case ProfileTOML:
+		return "toml"
+	case ProfileMapstructure:
+		return "mapstructure"
+	case ProfileXML:
+		return "xml"
+	case ProfileJSON, ProfileJSONv2:
+		fallthrough
+	default:
+		return DefaultTag
+	}
+}
+
+// isJSON returns true if the profile is one of the json packages.
+func (p Profile) isJSON() bool {
+	return p == ProfileJSON || p == ProfileJSONv2
+}
+
+// fieldName returns the member name for an untagged struct field.
+func (p Profile) fieldName(field reflect.StructField) string {
+	if p == ProfileYAML {
+		return strings.ToLower(field.Name)
+	}
+
+	return field.Name
+}
+
+// flatten returns true if the struct field's members are added to its parent,
+// and whether the field holds the members no other field handles, an index signature.
+// The typ is the field type, after following an unnamed pointer.
+func (p Profile) flatten(field structField, typ reflect.Type) (bool, bool) {
+	var option bool
+
+	switch p {
+	case ProfileYAML:
+		option = field.hasOpt("inline")
+	case ProfileMapstructure:
+		if field.hasOpt("remain") {
+			return false, isFallback(typ)
+		}
+
+		option = field.hasOpt("squash")
+	case ProfileJSONv2:
+		option = field.hasOpt("inline") || field.hasOpt("embed") || field.hasOpt("unknown")
+		if !option {
+			return !field.tagged && field.field.Anonymous && typ.Kind() == reflect.Struct, false
+		}
+	case ProfileJSON, ProfileTOML, ProfileXML:
+		fallthrough
+	default:
+		// Untagged embedded structs are always flattened.
+		return !field.tagged && field.field.Anonymous && typ.Kind() == reflect.Struct, false
+	}
+
+	switch {
+	case option && isFallback(typ):
+		return false, true
+	case option && typ.Kind() == reflect.Struct:
+		return true, false
+	default:
+		return false, false
+	}
+}
+
+// isNilable returns true if the marshaller may encode the type as null.
+// json v2 and yaml encode nil slices and maps as empty arrays and objects. TOML and XML have no null.
+func (g *Goty) isNilable(field reflect.Type) bool {
+	switch {
+	case g.config.Profile == ProfileTOML, g.config.Profile == ProfileXML:
+		return false
+	case field.Kind() == reflect.Ptr, field.Kind() == reflect.Interface:
+		return true
+	case field.Kind() == reflect.Slice, field.Kind() == reflect.Map:
+		return g.config.Profile != ProfileJSONv2 && g.config.Profile != ProfileYAML
+	default:
+		return false
+	}
+}
+
+// hasNil returns true if the go type has a nil value.
+func hasNil(field reflect.Type) bool {
+	switch field.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// omitted returns true if the struct tag options may leave the member out.
+// omitzero leaves out every zero value, including nil, so it works on any type.
+func (g *Goty) omitted(field structField) bool {
+	switch {
+	case (g.config.Profile == ProfileTOML || g.config.Profile == ProfileXML) && hasNil(field.field.Type):
+		return true // TOML and XML leave out nil values.
+	case field.hasOpt("omitzero"):
+		return true
+	case g.config.Profile == ProfileXML && field.hasOpt("comment"):
+		return true // encoding/xml does not write empty comments.
+	case !field.hasOpt("omitempty"):
+		return false
+	case g.config.Profile == ProfileJSONv2:
+		return g.encodesEmpty(field.field.Type)
+	case g.config.Profile == ProfileJSON, g.config.Profile == ProfileXML:
+		return omitsEmpty(field.field.Type)
+	default:
+		return true // The other marshallers leave out all zero values, including structs.
+	}
+}
+
+// encodesEmpty returns true if json v2 may encode a value of this type as null, "", {} or [].
+// That's what the v2 omitempty option leaves out. Numbers and booleans are never empty.
+// A struct is {} when every member may be left out.
+func (g *Goty) encodesEmpty(field reflect.Type) bool {
+	switch field.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.String, reflect.Slice, reflect.Map:
+		return true
+	case reflect.Array:
+		return field.Len() == 0
+	case reflect.Struct:
+		if isMarshaler(field) {
+			return true
+		}
+
+		for _, member := range g.structFields(field) {
+			if !g.omitted(member) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return isMarshaler(field) // These can produce anything.
+	}
+}
+
+// quotable returns true if the `string` tag option works on the type.
+// json v2 only quotes numbers. The other marshallers do not have the option.
+func (g *Goty) quotable(field reflect.Type) bool {
+	switch g.config.Profile {
+	case ProfileJSON:
+		return isQuotable(field)
+	case ProfileJSONv2:
+	default:
+		return false
+	}
+
+	if field.Name() == "" && field.Kind() == reflect.Ptr {
+		field = field.Elem()
+	}
+
+	return isQuotable(field) && field.Kind() != reflect.Bool && field.Kind() != reflect.String
+}
+
+// profileType returns the typescript type for go types the profile's marshaller handles itself.
+// This is checked before the mappings, because the mappings are what encoding/json does.
+func (g *Goty) profileType(field reflect.Type) (string, bool) {
+	if (g.config.Profile == ProfileYAML || g.config.Profile == ProfileTOML) && field == durationType {
+		return "string", true // yaml and toml use Duration.String().
+	}
+
+	return "", false
+}
+
+// checkMarshaler detects types that marshal themselves.
+// The json packages prefer json.Marshaler over encoding.TextMarshaler, and so do we.
+// yaml, toml and xml have their own marshaler interfaces, and mapstructure does not marshal.
+// A marshaler can produce anything, so it becomes `any` unless it has a type override.
+// Both value and pointer receivers are checked; pointers are dereferenced by the caller first.
+func (g *Goty) checkMarshaler(field reflect.Type) (string, bool) {
+	if field.Kind() == reflect.Ptr || field.Kind() == reflect.Interface {
+		return "", false
+	}
+
+	switch p := g.config.Profile; {
+	case p == ProfileMapstructure:
+		return "", false
+	case p.isJSON() && implements(field, jsonMarshaler),
+		p == ProfileYAML && hasMethod(field, "MarshalYAML"),
+		p == ProfileTOML && hasMethod(field, "MarshalTOML"),
+		p == ProfileXML && hasMethod(field, "MarshalXML"):
+		return "any", true
+	case implements(field, textMarshaler):
+		return "string", true
+	default:
+		return "", false
+	}
+}
+
+// hasMethod returns true if the type, or a pointer to the type, has the method.
+// This finds marshaler methods from packages we do not import.
+func hasMethod(field reflect.Type, name string) bool {
+	_, ok := reflect.PointerTo(field).MethodByName(name)
+	return ok
+}
+
+// isBytes returns true if the type is a byte slice the marshaller encodes as a string.
+// toml, yaml and mapstructure keep byte slices as arrays of numbers.
+func (g *Goty) isBytes(field reflect.Type) bool {
+	switch g.config.Profile {
+	case ProfileTOML, ProfileYAML, ProfileMapstructure:
+		return false
+	case ProfileJSON, ProfileJSONv2, ProfileXML:
+		fallthrough
+	default:
+		return isByteSlice(field)
+	}
+}
+
+// isByteArray returns true if the type is an array json v2 encodes as base64.
+func (g *Goty) isByteArray(field reflect.Type) bool {
+	return g.config.Profile == ProfileJSONv2 && field.Kind() == reflect.Array &&
+		field.Elem().Kind() == reflect.Uint8 && !isMarshaler(field.Elem())
+}
+
+// parseFormat changes the member type for the json v2 format tag option.
+// Unknown formats, and formats on types that do not have them, are ignored.
+func (g *Goty) parseFormat(parent *DataStruct, field structField, member *StructMember, val reflect.Value) {
+	typ := field.field.Type
+	if typ.Kind() == reflect.Ptr {
+		typ, val = typ.Elem(), sampleElem(val)
+	}
+
+	switch {
+	case typ == timeType:
+		member.Type = "string"
+		if field.format == "unix" || field.format == "unixmilli" ||
+			field.format == "unixmicro" || field.format == "unixnano" {
+			member.Type = "number"
+		}
+	case typ == durationType:
+		switch field.format {
+		case "sec", "milli", "micro", "nano":
+			member.Type = "number"
+		case "units", "iso8601":
+			member.Type = "string"
+		}
+	case isByteSlice(typ) && field.format == "array":
+		member.Type = "number[]"
+	case g.isByteArray(typ) && field.format == "array":
+		member.Type = g.parseTuple(parent, typ, member, val)
+	case (typ.Kind() == reflect.Float32 || typ.Kind() == reflect.Float64) && field.format == "nonfinite":
+		member.Type = "number | string" // NaN and Infinity are strings.
+	case (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map) && field.format == "emitnull":
+		member.Nullable = true
+	case (typ.Kind() == reflect.Slice || typ.Kind() == reflect.Map) && field.format == "emitempty":
+		member.Nullable = field.field.Type.Kind() == reflect.Ptr
+	}
+}
+
+// indexSignature returns the type for an index signature member, ie. `[key: string]: Type`.
+// Typescript requires every other member to fit the index signature type (TS2411), so it's a
+// union of the map value type and the types of the other members. Extended interfaces add
+// all of their member types, ie. Base[keyof Base]. Optional members add undefined.
+func indexSignature(data *DataStruct, signature *StructMember) string {
+	name := signature.Type
+	if types := splitUnion(name); slices.Contains(types, "any") || slices.Contains(types, "unknown") {
+		return name // Everything fits already.
+	}
+
+	for _, extends := range data.Extends {
+		name = unionTypes(name, extends+"[keyof "+extends+"]")
+	}
+
+	for _, member := range data.Members {
+		typ := member.Type
+
+		switch {
+		case member == signature:
+			continue
+		case member.Members != nil && typ == "":
+			typ = "object" // Anonymous structs and nested xml elements are printed inline.
+		}
+
+		if member.Nullable {
+			typ = unionTypes(typ, "null")
+		}
+
+		if member.Optional {
+			typ = unionTypes(typ, "undefined")
+		}
+
+		name = unionTypes(name, typ)
+	}
+
+	return name
+}
+
+// parseFallback returns the type of a json v2 index signature, ie. `[key: string]: Type`.
+// A jsontext.Value may hold anything.
+func (g *Goty) parseFallback(parent *DataStruct, field reflect.Type, member *StructMember, val reflect.Value) string {
+	if field.Kind() == reflect.Ptr {
+		field, val = field.Elem(), sampleElem(val)
+	}
+
+	if isJSONTextValue(field) {
+		return "any"
+	}
+
+	name, nullable := g.parseValues(parent, field.Elem(), member, sampleElems(val))
+	if nullable {
+		name = "null | " + name
+	}
+
+	return name
+}
@@ -0,0 +1,291 @@
+package goty_test
+
+import (
+	"encoding/xml"
+	"maps"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"golift.io/goty"
+)
+
+type TestAddress struct {
+	City string `json:"city"`
+}
+
+// TestCursor encodes as {} when it's empty, so json v2 omitempty leaves it out.
+type TestCursor struct {
+	Next string `json:"next,omitempty"`
+	Page int    `json:"page,omitzero"`
+}
+
+type TestProfile struct {
+	Tags     []string          `json:"tags"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Count    int               `json:"count,omitempty"`
+	Hash     [4]byte           `json:"hash"`
+	Raw      []byte            `json:"raw,format:array"`
+	Created  time.Time         `json:"created,format:unix"`
+	Timeout  time.Duration     `json:"timeout,format:units"`
+	Comma    string            `json:"'a,b',omitzero"`
+	Score    float64           `json:"score,string"`
+	Address  TestAddress       `json:",inline"`
+	Optional *TestAddress      `json:"optional,omitzero"`
+	Home     TestAddress       `json:"home,omitempty"`
+	Cursor   TestCursor        `json:"cursor,omitempty"`
+	Extra    map[string]any    `json:",unknown"`
+}
+
+func ExampleProfile() {
+	goty := goty.NewGoty(&goty.Config{Profile: goty.ProfileJSONv2})
+	goty.Parse(TestProfile{})
+	goty.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestProfile>
+	//  */
+	// export interface TestProfile extends TestAddress {
+	//   tags: string[];
+	//   labels?: Record<string, string>;
+	//   count: number;
+	//   hash: string;
+	//   raw: number[];
+	//   created: number;
+	//   timeout: string;
+	//   "a,b"?: string;
+	//   score: string;
+	//   optional?: TestAddress;
+	//   home: TestAddress;
+	//   cursor?: TestCursor;
+	//   [key: string]: null | any;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestAddress>
+	//  */
+	// export interface TestAddress {
+	//   city: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestCursor>
+	//  */
+	// export interface TestCursor {
+	//   next?: string;
+	//   page?: number;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+type TestProfiles struct {
+	TestAddress `yaml:",inline" mapstructure:",squash"`
+
+	UserName string            `yaml:"" toml:"user_name" mapstructure:"user_name"`
+	Timeout  time.Duration     `yaml:"timeout,omitempty" toml:"timeout" mapstructure:"timeout"`
+	Home     *TestAddress      `yaml:"home" toml:"home" mapstructure:"home"`
+	Tags     []string          `yaml:"tags,flow" toml:"tags,omitempty" mapstructure:"tags"`
+	Extra    map[string]string `yaml:",inline" toml:"extra" mapstructure:",remain"`
+}
+
+func ExampleProfile_marshallers() {
+	for _, profile := range []goty.Profile{goty.ProfileYAML, goty.ProfileTOML, goty.ProfileMapstructure} {
+		goty := goty.NewGoty(&goty.Config{Profile: profile})
+		goty.Parse(TestProfiles{})
+
+		goty.Values()[0].Print("", os.Stdout)
+	}
+	// Output:
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestProfiles>
+	//  */
+	// export interface TestProfiles extends TestAddress {
+	//   username: string;
+	//   timeout?: string;
+	//   home: TestAddress | null;
+	//   tags: string[];
+	//   [key: string]: string | TestAddress[keyof TestAddress] | undefined | TestAddress | null | string[];
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestProfiles>
+	//  */
+	// export interface TestProfiles extends TestAddress {
+	//   user_name: string;
+	//   timeout: string;
+	//   home?: TestAddress;
+	//   tags?: string[];
+	//   extra?: Record<string, string>;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestProfiles>
+	//  */
+	// export interface TestProfiles extends TestAddress {
+	//   user_name: string;
+	//   timeout: number;
+	//   home: TestAddress | null;
+	//   tags: string[] | null;
+	//   [key: string]: string | TestAddress[keyof TestAddress] | number | TestAddress | null | string[];
+	// };
+}
+
+type TestXML struct {
+	XMLName xml.Name `xml:"server"`
+	ID      int      `xml:"id,attr"`
+	Name    string   `xml:",chardata"`
+	Note    string   `xml:",comment"`
+	Host    string   `xml:"net>host"`
+	Port    *int     `xml:"net>port"`
+	Mask    string   `xml:"net>ipv4>mask,omitempty"`
+	Raw     string   `xml:",innerxml"`
+	Tags    []string `xml:"tags>tag"`
+}
+
+func ExampleProfile_xml() {
+	goty := goty.NewGoty(&goty.Config{Profile: goty.ProfileXML})
+	goty.Parse(TestXML{})
+	goty.Values()[0].Print("", os.Stdout)
+	// Output:
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestXML>
+	//  */
+	// export interface TestXML {
+	//   id: number;
+	//   "#text": string;
+	//   "#comment"?: string;
+	//   net: {
+	//     host: string;
+	//     port?: number;
+	//     ipv4?: {
+	//       mask?: string;
+	//     };
+	//   };
+	//   Raw: string;
+	//   tags?: {
+	//     tag?: string[];
+	//   };
+	// };
+}
+
+type TestInline struct {
+	Name  string            `json:"name"`
+	Ptr   *int              `json:"ptr"`
+	Count int               `json:"count,omitempty"`
+	Extra map[string]string `json:",inline"`
+}
+
+// Every member must fit the index signature type, so it's a union of the member types.
+func ExampleProfile_inline() {
+	goty := goty.NewGoty(&goty.Config{Profile: goty.ProfileJSONv2})
+	goty.Parse(TestInline{})
+	goty.Values()[0].Print("", os.Stdout)
+	// Output:
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestInline>
+	//  */
+	// export interface TestInline {
+	//   name: string;
+	//   ptr: number | null;
+	//   count: number;
+	//   [key: string]: string | number | null;
+	// };
+}
+
+type TestRemain struct {
+	Port   int `yaml:"port" mapstructure:"port"`
+	Server struct {
+		Host string `yaml:"host" mapstructure:"host"`
+	} `yaml:"server" mapstructure:"server"`
+	Extra map[string]bool `yaml:",inline" mapstructure:",remain"`
+}
+
+// The yaml inline and mapstructure remain maps have siblings with other types.
+func ExampleProfile_remain() {
+	for _, profile := range []goty.Profile{goty.ProfileYAML, goty.ProfileMapstructure} {
+		goty := goty.NewGoty(&goty.Config{Profile: profile})
+		goty.Parse(TestRemain{})
+
+		goty.Values()[0].Print("", os.Stdout)
+	}
+	// Output:
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestRemain>
+	//  */
+	// export interface TestRemain {
+	//   port: number;
+	//   server: {
+	//     host: string;
+	//   };
+	//   [key: string]: boolean | number | object;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestRemain>
+	//  */
+	// export interface TestRemain {
+	//   port: number;
+	//   server: {
+	//     host: string;
+	//   };
+	//   [key: string]: boolean | number | object;
+	// };
+}
+
+type testYAML struct {
+	Tags    []string          `yaml:"tags"`
+	Labels  map[string]string `yaml:"labels"`
+	Raw     []byte            `yaml:"raw"`
+	Ptr     *int              `yaml:"ptr"`
+	Timeout time.Duration     `yaml:"timeout"`
+}
+
+// testYAMLWire is what gopkg.in/yaml.v3 v3.0.1 encodes for testYAML{Raw: []byte("hi")}.
+// goty does not import yaml, so the output is recorded here.
+const testYAMLWire = `tags: []
+labels: {}
+raw:
+    - 104
+    - 105
+ptr: null
+timeout: 0s
+`
+
+// TestYAML makes sure the yaml profile matches what yaml.v3 encodes.
+func TestYAML(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		wire   string
+		member string
+		want   string
+	}{
+		{wire: "tags: []", member: "tags", want: "string[]"},
+		{wire: "labels: {}", member: "labels", want: "Record<string, string>"},
+		{wire: "raw:\n    - 104", member: "raw", want: "number[]"},
+		{wire: "ptr: null", member: "ptr", want: "number | null"},
+		{wire: "timeout: 0s", member: "timeout", want: "string"},
+	}
+
+	got := tsTypes(goty.NewGoty(&goty.Config{Profile: goty.ProfileYAML}).Parse(testYAML{}).Values()[0])
+	want := map[string]string{}
+
+	for _, test := range tests {
+		if !strings.Contains(testYAMLWire, test.wire) {
+			t.Errorf("%s: %q is not in the yaml output", test.member, test.wire)
+		}
+
+		want[test.member] = test.want
+	}
+
+	if !maps.Equal(got, want) {
+		t.Errorf("wrong member types\n got: %v\nwant: %v", got, want)
+	}
+}
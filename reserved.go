@@ -0,0 +1,43 @@
+package goty
+
+// reservedNames are typescript keywords, global types from lib.d.ts and the names goty uses in
+// its own output. An interface with one of these names would shadow the global type,
+// so they are treated like names that are already taken.
+//
+//nolint:gochecknoglobals // It's a lookup table.
+var reservedNames = map[string]bool{
+	// Keywords and primitive types.
+	"any": true, "as": true, "bigint": true, "boolean": true, "break": true, "case": true, "catch": true,
+	"class": true, "const": true, "continue": true, "debugger": true, "declare": true, "default": true,
+	"delete": true, "do": true, "else": true, "enum": true, "export": true, "extends": true, "false": true,
+	"finally": true, "for": true, "function": true, "if": true, "implements": true, "import": true,
+	"in": true, "infer": true, "instanceof": true, "interface": true, "keyof": true, "let": true,
+	"module": true, "namespace": true, "never": true, "new": true, "null": true, "number": true,
+	"object": true, "package": true, "private": true, "protected": true, "public": true, "readonly": true,
+	"return": true, "static": true, "string": true, "super": true, "switch": true, "symbol": true,
+	"this": true, "throw": true, "true": true, "try": true, "type": true, "typeof": true, "undefined": true,
+	"unique": true, "unknown": true, "var": true, "void": true, "while": true, "with": true, "yield": true,
+	// Global objects.
+	"Array": true, "ArrayBuffer": true, "Atomics": true, "BigInt": true, "BigInt64Array": true,
+	"BigUint64Array": true, "Boolean": true, "DataView": true, "Date": true, "Error": true,
+	"EvalError": true, "Float32Array": true, "Float64Array": true, "Function": true, "Generator": true,
+	"Infinity": true, "Int8Array": true, "Int16Array": true, "Int32Array": true, "Intl": true,
+	"Iterator": true, "JSON": true, "Map": true, "Math": true, "NaN": true, "Number": true, "Object": true,
+	"Promise": true, "Proxy": true, "RangeError": true, "ReferenceError": true, "Reflect": true,
+	"RegExp": true, "Set": true, "SharedArrayBuffer": true, "String": true, "Symbol": true,
+	"SyntaxError": true, "TypeError": true, "URIError": true, "Uint8Array": true, "Uint8ClampedArray": true,
+	"Uint16Array": true, "Uint32Array": true, "WeakMap": true, "WeakRef": true, "WeakSet": true,
+	// Utility types. goty uses Record, Omit and Partial in its output.
+	"ArrayLike": true, "AsyncIterable": true, "Awaited": true, "Capitalize": true,
+	"ConstructorParameters": true, "Exclude": true, "Extract": true, "InstanceType": true, "Iterable": true,
+	"IterableIterator": true, "Lowercase": true, "NoInfer": true, "NonNullable": true, "Omit": true,
+	"OmitThisParameter": true, "Parameters": true, "Partial": true, "Pick": true, "PromiseLike": true,
+	"PropertyKey": true, "Readonly": true, "ReadonlyArray": true, "ReadonlyMap": true, "ReadonlySet": true,
+	"Record": true, "Required": true, "ReturnType": true, "ThisParameterType": true, "ThisType": true,
+	"Uncapitalize": true, "Uppercase": true,
+}
+
+// nameTaken returns true if an interface name is already used, or reserved by typescript.
+func (g *Goty) nameTaken(name string) bool {
+	return g.structNames[name] || reservedNames[name]
+}
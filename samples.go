@@ -0,0 +1,189 @@
+package goty
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Samples are only used when Config.Samples is true. A sample is a populated value passed
+// into Parse(). Everything in it is inspected, and the concrete types found inside interface
+// members, maps and slices are used instead of `any`. Different types become a union.
+
+// getSample returns the value to parse samples from, if samples are enabled.
+func (g *Goty) getSample(elem any) reflect.Value {
+	if _, ok := elem.(reflect.Type); ok || !g.config.Samples {
+		return reflect.Value{}
+	}
+
+	return reflect.ValueOf(elem)
+}
+
+// sampleElem returns the value a sample pointer points to.
+func sampleElem(val reflect.Value) reflect.Value {
+	if !val.IsValid() || val.IsNil() {
+		return reflect.Value{}
+	}
+
+	return val.Elem()
+}
+
+// sampleIndex returns a struct member from a sample. The index goes through embedded structs.
+func sampleIndex(val reflect.Value, index []int) reflect.Value {
+	for _, idx := range index {
+		if val.Kind() == reflect.Ptr {
+			val = sampleElem(val)
+		}
+
+		if !val.IsValid() {
+			return reflect.Value{}
+		}
+
+		val = val.Field(idx)
+	}
+
+	return val
+}
+
+// sampleElems returns the elements in a sample slice or array, or the values in a sample map.
+func sampleElems(val reflect.Value) []reflect.Value {
+	if !val.IsValid() {
+		return nil
+	}
+
+	if val.Kind() == reflect.Map {
+		// Sort the keys, so the union types are always in the same order.
+		keys := val.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int {
+			return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+		})
+
+		vals := make([]reflect.Value, len(keys))
+		for idx, key := range keys {
+			vals[idx] = val.MapIndex(key)
+		}
+
+		return vals
+	}
+
+	vals := make([]reflect.Value, val.Len())
+	for idx := range vals {
+		vals[idx] = val.Index(idx)
+	}
+
+	return vals
+}
+
+// parseValues returns the typescript type for a list of samples of the same go type.
+// Different sample types become a union. Without any samples, the go type is parsed.
+func (g *Goty) parseValues(parent *DataStruct, field reflect.Type, member *StructMember, vals []reflect.Value) (string, bool) {
+	if len(vals) == 0 {
+		return g.parseMember(parent, field, member)
+	}
+
+	var name string
+
+	var nullable bool
+
+	for _, val := range vals {
+		typ, null := g.parseValue(parent, field, member, val)
+		name = unionTypes(name, typ)
+		nullable = nullable || null
+	}
+
+	return name, nullable
+}
+
+// sample is an addressable struct sample that was parsed.
+type sample struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+// mergeSample parses a sample of a struct that was already parsed.
+// Members with different types become unions.
+func (g *Goty) mergeSample(data *DataStruct, val reflect.Value) {
+	if !val.IsValid() || data.Type.Kind() != reflect.Struct || len(data.TypeParams) > 0 {
+		return
+	}
+
+	if val.CanAddr() { // Pointers may point back to a struct we are in the middle of.
+		// A struct and its first field have the same address, so the type is part of the key.
+		key := sample{typ: data.Type, addr: val.Addr().Pointer()}
+		if g.sampled[key] {
+			return
+		}
+
+		g.sampled[key] = true
+	}
+
+	tmp := &DataStruct{
+		Name:    data.Name,
+		Type:    data.Type,
+		GoName:  data.GoName,
+		Members: make([]*StructMember, 0),
+		doc:     data.doc,
+		ovr:     data.ovr,
+	}
+
+	g.addStructMembers(tmp, data.Type, val)
+
+	for _, member := range tmp.Members {
+		for _, existing := range data.Members {
+			if existing.Name == member.Name && existing.Members == nil {
+				existing.Type = unionTypes(existing.Type, member.Type)
+			}
+		}
+	}
+}
+
+// unionTypes combines two typescript types into a union.
+// An `any` only means we had no sample, so it's dropped when there are other types.
+func unionTypes(first, second string) string {
+	types := []string{}
+
+	for _, typ := range append(splitUnion(first), splitUnion(second)...) {
+		if typ != "" && !slices.Contains(types, typ) {
+			types = append(types, typ)
+		}
+	}
+
+	if len(types) > 1 {
+		types = slices.DeleteFunc(types, func(typ string) bool { return typ == "any" })
+	}
+
+	return strings.Join(types, " | ")
+}
+
+// splitUnion splits a typescript union type into its types.
+// Unions inside brackets, like Record<string, null | number>, are left alone.
+func splitUnion(typ string) []string {
+	types := []string{}
+	depth, last := 0, 0
+
+	for idx := range len(typ) {
+		switch typ[idx] {
+		case '<', '(', '[', '{':
+			depth++
+		case '>', ')', ']', '}':
+			depth--
+		case '|':
+			if depth == 0 {
+				types = append(types, strings.TrimSpace(typ[last:idx]))
+				last = idx + 1
+			}
+		}
+	}
+
+	return append(types, strings.TrimSpace(typ[last:]))
+}
+
+// wrapUnion wraps a union type in parentheses, so it can be used as an array element.
+func wrapUnion(typ string) string {
+	if len(splitUnion(typ)) > 1 {
+		return "(" + typ + ")"
+	}
+
+	return typ
+}
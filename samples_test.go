@@ -0,0 +1,46 @@
+package goty_test
+
+import "golift.io/goty"
+
+type (
+	// TestOuter has a struct at offset 0, so the struct and the field have the same address.
+	TestOuter struct {
+		In    TestInner `json:"in"`
+		Extra any       `json:"extra"`
+	}
+	TestInner struct {
+		V any `json:"v"`
+	}
+)
+
+// Samples that disagree become unions. Every sample is used, even structs that share an address.
+func ExampleConfig_samples() {
+	builder := goty.NewGoty(&goty.Config{Samples: true})
+	builder.Parse(&[]TestOuter{
+		{In: TestInner{V: 1}, Extra: true},
+		{In: TestInner{V: "s"}, Extra: []string{"a"}},
+	})
+	builder.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestOuter>
+	//  */
+	// export interface TestOuter {
+	//   in: TestInner;
+	//   extra: boolean | string[] | null;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestInner>
+	//  */
+	// export interface TestInner {
+	//   v: number | string | null;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
@@ -0,0 +1,129 @@
+package goty
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrTagOption is the panic when a goty struct tag has an option we do not know.
+var ErrTagOption = errors.New("unknown goty tag option")
+
+// gotyOptions are the options in the goty struct tag, ie. `goty:"type=string,optional,readonly,name=hostId"`.
+// An option with a value starts a list, and the bare values after it continue the list.
+// Types may have commas, like Record<string, number>, so a type continues until its brackets are closed.
+type gotyOptions struct {
+	// groups are the field groups the field belongs to. See View.Groups.
+	groups []string
+	// override has the member options from the tag.
+	override FieldOverride
+	// err is the problem with the tag, if any. It's reported when the member is added.
+	err error
+}
+
+// parseGotyTag returns the options in a goty struct tag.
+func parseGotyTag(tag string) gotyOptions {
+	var (
+		output gotyOptions
+		key    string
+	)
+
+	if tag == "" {
+		return output
+	}
+
+	for _, value := range strings.Split(tag, ",") {
+		name, rest, hasValue := strings.Cut(value, "=")
+
+		switch {
+		case key == "type" && !balanced(output.override.Type):
+			output.override.Type += "," + value
+			continue
+		case hasValue:
+			key, value = strings.TrimSpace(name), strings.TrimSpace(rest)
+		case output.flag(strings.TrimSpace(value)):
+			continue
+		case key != "groups":
+			output.err = fmt.Errorf("%q: %w", value, ErrTagOption)
+			return output
+		}
+
+		output.set(key, strings.TrimSpace(value))
+	}
+
+	return output
+}
+
+// flag sets an option without a value. Returns false if it's not one.
+func (o *gotyOptions) flag(name string) bool {
+	switch name {
+	case "optional":
+		o.override.Optional = true
+	case "nullable":
+		o.override.Nullable = true
+	case "readonly":
+		o.override.Readonly = true
+	case "skip":
+		o.override.Skip = true
+	default:
+		return false
+	}
+
+	return true
+}
+
+// set sets an option with a value.
+func (o *gotyOptions) set(key, value string) {
+	switch key {
+	case "groups":
+		if value != "" {
+			o.groups = append(o.groups, value)
+		}
+	case "type":
+		o.override.Type = value
+	case "name":
+		o.override.Name = value
+	case "comment":
+		o.override.Comment = value
+	default:
+		o.err = fmt.Errorf("%q: %w", key, ErrTagOption)
+	}
+}
+
+// balanced returns true if every bracket in a typescript type is closed.
+func balanced(typ string) bool {
+	depth := 0
+
+	for _, char := range typ {
+		switch char {
+		case '<', '{', '(', '[':
+			depth++
+		case '>', '}', ')', ']':
+			depth--
+		}
+	}
+
+	return depth <= 0
+}
+
+// merge returns the tag's member options with the options from the config on top.
+func (o FieldOverride) merge(config FieldOverride) FieldOverride {
+	if config.Type != "" {
+		o.Type = config.Type
+	}
+
+	if config.Name != "" {
+		o.Name = config.Name
+	}
+
+	if config.Comment != "" {
+		o.Comment = config.Comment
+	}
+
+	o.Optional = o.Optional || config.Optional
+	o.Nullable = o.Nullable || config.Nullable
+	o.Readonly = o.Readonly || config.Readonly
+	o.Skip = o.Skip || config.Skip
+
+	return o
+}
@@ -0,0 +1,115 @@
+package goty
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Implementation is used as an input to the Union method.
+// Use this to add a concrete type that implements a go interface.
+type Implementation struct {
+	// Type is a value, or a reflect.Type, of the type that implements the interface.
+	Type any
+	// Value is the discriminator value for this implementation. It's converted using json.Marshal.
+	// This is ignored if the union has no discriminator.
+	Value any
+}
+
+// Union adds a go interface type to the builder as a typescript union of its implementations.
+// ie. `export type Event = LoginEvent | LogoutEvent;`. The iface is a pointer to the
+// interface, ie. (*Event)(nil), or its reflect.Type. If a discriminator is provided, it's the
+// json member name that tells the implementations apart. Each implementation in the union gets
+// that member as a literal type, ie. `(LoginEvent & { type: "login" })`. The implementation's own
+// interface does not change, because the go struct may be used, and marshalled, outside the union.
+// A union without implementations is `never`. Add unions before parsing the structs that use them.
+// With views, each view parses the implementations with its own tag and profile.
+func (g *Goty) Union(iface any, discriminator string, impls ...Implementation) *Goty {
+	typ := getType(iface)
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Interface {
+		panic("expected an interface, got " + typ.String())
+	}
+
+	union := &union{typ: typ, discriminator: discriminator, impls: impls}
+	if len(g.config.Views) == 0 {
+		g.addUnion(union)
+		return g
+	}
+
+	g.unions = append(g.unions, union)
+
+	return g.parseViews(nil)
+}
+
+// union is the input to the Union method. Views keep these to parse the union in each view.
+type union struct {
+	typ           reflect.Type
+	discriminator string
+	impls         []Implementation
+}
+
+// addUnion adds a union and its implementations to the builder.
+func (g *Goty) addUnion(union *union) {
+	data := &DataStruct{
+		Name:   g.getStructName(union.typ),
+		Type:   union.typ,
+		GoName: union.typ.PkgPath() + "." + union.typ.Name(),
+		doc:    g.config,
+		ovr:    g.config.override(union.typ),
+	}
+
+	if g.structNames[data.Name] {
+		panic("cannot find a suitable struct name for " + data.GoName + ": " + data.Name)
+	}
+
+	g.structTypes[union.typ] = data
+	g.structNames[data.Name] = true
+	g.output = append(g.output, data)
+	g.pkgPaths[union.typ.PkgPath()] = struct{}{}
+
+	names := make([]string, len(union.impls))
+	for idx, impl := range union.impls {
+		names[idx] = g.parseImplementation(data, impl, union.discriminator)
+	}
+
+	data.Alias = strings.Join(names, " | ")
+	if data.Alias == "" {
+		data.Alias = "never" // Nothing implements this interface.
+	}
+}
+
+// parseImplementation adds one of the union's implementations to the builder, and returns its name.
+func (g *Goty) parseImplementation(union *DataStruct, impl Implementation, discriminator string) string {
+	typ := getType(impl.Type)
+	if !typ.Implements(union.Type) {
+		panic(typ.String() + " does not implement " + union.GoName)
+	}
+
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	name, _ := g.parseMember(union, typ, &StructMember{})
+	if discriminator == "" {
+		return name
+	}
+
+	if typ.Kind() != reflect.Struct {
+		panic("union discriminators only work with struct implementations: " + typ.String())
+	}
+
+	value, err := json.Marshal(impl.Value)
+	if err != nil {
+		panic("cannot marshal discriminator value: " + err.Error())
+	}
+
+	if !isIdentifier(discriminator) {
+		discriminator = quote(discriminator)
+	}
+
+	return "(" + name + " & { " + discriminator + ": " + string(value) + " })"
+}
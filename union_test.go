@@ -0,0 +1,120 @@
+package goty_test
+
+import (
+	"strings"
+	"testing"
+
+	"golift.io/goty"
+)
+
+type (
+	TestEvent interface{ isEvent() }
+	// TestLogin implements TestEvent with a value receiver, and has the discriminator field.
+	TestLogin struct {
+		Type string `json:"type"`
+		User string `json:"user"`
+	}
+	// TestLogout implements TestEvent with a pointer receiver, and has no discriminator field.
+	TestLogout struct {
+		Reason string `json:"reason"`
+	}
+	TestEvents struct {
+		Events []TestEvent `json:"events"`
+		Last   TestEvent   `json:"last"`
+	}
+	testNotEvent  struct{}
+	testEventName string
+	// TestNothing has no implementations, so it's never.
+	TestNothing interface{ isNothing() }
+)
+
+func (TestLogin) isEvent()     {}
+func (*TestLogout) isEvent()   {}
+func (testEventName) isEvent() {}
+
+func ExampleGoty_Union() {
+	builder := goty.NewGoty(nil)
+	builder.Union((*TestEvent)(nil), "type",
+		goty.Implementation{Type: TestLogin{}, Value: "login"},
+		goty.Implementation{Type: &TestLogout{}, Value: "logout"},
+	)
+	builder.Union((*TestNothing)(nil), "type")
+	builder.Parse(TestEvents{})
+	builder.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestEvent>
+	//  */
+	// export type TestEvent = (TestLogin & { type: "login" }) | (TestLogout & { type: "logout" });
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestLogin>
+	//  */
+	// export interface TestLogin {
+	//   type: string;
+	//   user: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestLogout>
+	//  */
+	// export interface TestLogout {
+	//   reason: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestNothing>
+	//  */
+	// export type TestNothing = never;
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestEvents>
+	//  */
+	// export interface TestEvents {
+	//   events: TestEvent[] | null;
+	//   last: TestEvent | null;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+func TestUnionPanics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		iface any
+		disc  string
+		impl  goty.Implementation
+		panic string
+	}{
+		{name: "not an interface", iface: TestLogin{}, panic: "expected an interface"},
+		{name: "not implemented", iface: (*TestEvent)(nil), impl: goty.Implementation{Type: testNotEvent{}},
+			panic: "does not implement"},
+		{name: "pointer receiver", iface: (*TestEvent)(nil), impl: goty.Implementation{Type: TestLogout{}},
+			panic: "does not implement"},
+		{name: "not a struct", iface: (*TestEvent)(nil), disc: "type",
+			impl: goty.Implementation{Type: testEventName(""), Value: "name"}, panic: "only work with struct"},
+		{name: "bad value", iface: (*TestEvent)(nil), disc: "type",
+			impl: goty.Implementation{Type: TestLogin{}, Value: make(chan int)}, panic: "cannot marshal"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			defer func() {
+				if err, _ := recover().(string); !strings.Contains(err, test.panic) {
+					t.Errorf("expected a panic with %q, got: %v", test.panic, err)
+				}
+			}()
+
+			goty.NewGoty(nil).Union(test.iface, test.disc, test.impl)
+		})
+	}
+}
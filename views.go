@@ -0,0 +1,236 @@
+package goty
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// Views let one builder emit the same go types for more than one marshaller, ie. JSON and TOML.
+// Each view is parsed in a scratch builder first, and types that print differently get the view's
+// suffix. Types that use a suffixed type are different too, so that repeats until nothing changes.
+// Types that are the same in every view are shared, and only printed once.
+// Unions are parsed in each view too, because their implementations may be different.
+// A view with groups leaves out the fields in other groups. Those views are also compared to a
+// view with every field, so a type with fewer members is renamed, even with only one view.
+
+// View is one encoding of the parsed types, with its own struct tag and marshaller profile.
+type View struct {
+	// Prefix is prepended to the names of interfaces that are different in this view, ie. Public.
+	Prefix string `json:"prefix" toml:"prefix" xml:"prefix" yaml:"prefix"`
+	// Suffix is appended to the names of interfaces that are different in this view, ie. JSON.
+	Suffix string `json:"suffix" toml:"suffix" xml:"suffix" yaml:"suffix"`
+	// Tag is the struct tag this view reads. Default is the profile's tag.
+	Tag string `json:"tag" toml:"tag" xml:"tag" yaml:"tag"`
+	// Profile is the marshaller for this view.
+	Profile Profile `json:"profile" toml:"profile" xml:"profile" yaml:"profile"`
+	// Groups are the field groups in this view, from the goty struct tag, ie. `goty:"groups=admin,internal"`.
+	// Fields without groups are always included. Fields with groups are included if one of them is listed.
+	// nil includes every field. An empty list leaves out every field that has a group.
+	Groups []string `json:"groups" toml:"groups" xml:"groups" yaml:"groups"`
+}
+
+// config returns a copy of the builder config that uses the view's tag and profile.
+func (v *View) config(config *Config) *Config {
+	output := *config
+	output.Views = nil
+	output.Profile = v.Profile
+	output.GlobalOverrides.Tag = v.Tag
+
+	if v.Tag == "" {
+		output.GlobalOverrides.Tag = v.Profile.tag()
+	}
+
+	return &output
+}
+
+// viewCache has the types that differ, as one view parsed them.
+// Each view gets them back on the next Parse, so they are only declared once.
+type viewCache struct {
+	structTypes map[reflect.Type]*DataStruct
+	generics    map[string]*DataStruct
+	instances   map[reflect.Type]bool
+}
+
+// parseViews parses the elements once for each view.
+// The diffs include the elements from earlier calls, so shared types stay shared.
+func (g *Goty) parseViews(elems []any) *Goty {
+	g.viewElems = append(g.viewElems, elems...)
+	differ := g.viewDiffs(g.viewElems)
+	config := g.config
+
+	if len(g.viewCaches) != len(config.Views) {
+		g.viewCaches = make([]viewCache, len(config.Views))
+	}
+
+	for idx := range config.Views {
+		g.config, g.view, g.differ = config.Views[idx].config(config), &config.Views[idx], differ
+		g.restore(&g.viewCaches[idx], differ)
+		g.parseUnions()
+		g.Parse(elems...)
+		g.save(&g.viewCaches[idx], differ)
+	}
+
+	g.config, g.view, g.differ = config, nil, nil
+
+	return g
+}
+
+// viewDiffs returns the types that print differently in at least two views.
+func (g *Goty) viewDiffs(elems []any) map[reflect.Type]bool {
+	differ := make(map[reflect.Type]bool)
+
+	for found := true; found; {
+		found = false
+		signatures := make(map[reflect.Type]string)
+
+		for _, view := range g.compareViews() {
+			scratch := g.scratch(view, differ)
+			scratch.parseUnions()
+			scratch.Parse(elems...)
+
+			for _, data := range scratch.output {
+				signature := data.signature()
+				if prev, ok := signatures[data.Type]; !ok {
+					signatures[data.Type] = signature
+				} else if prev != signature && !differ[data.Type] {
+					differ[data.Type], found = true, true
+				}
+			}
+		}
+	}
+
+	return differ
+}
+
+// compareViews returns the views to compare. That's all of them,
+// and a view with every field when any of them have groups.
+func (g *Goty) compareViews() []*View {
+	views := make([]*View, 0, len(g.config.Views)+1)
+	grouped := false
+
+	for idx := range g.config.Views {
+		views = append(views, &g.config.Views[idx])
+		grouped = grouped || g.config.Views[idx].Groups != nil
+	}
+
+	if grouped {
+		views = append(views, &View{Tag: views[0].Tag, Profile: views[0].Profile})
+	}
+
+	return views
+}
+
+// scratch returns a new builder for a view, with the enums and unions from this builder.
+func (g *Goty) scratch(view *View, differ map[reflect.Type]bool) *Goty {
+	scratch := NewGoty(view.config(g.config))
+	scratch.view, scratch.differ, scratch.unions = view, differ, g.unions
+
+	for typ, data := range g.structTypes {
+		if len(data.Elements) > 0 {
+			scratch.structTypes[typ] = data
+			scratch.structNames[data.Name] = true
+		}
+	}
+
+	return scratch
+}
+
+// parseUnions adds the unions this view does not have yet.
+// A union that is the same in every view is only added once.
+func (g *Goty) parseUnions() {
+	for _, union := range g.unions {
+		if g.structTypes[union.typ] == nil {
+			g.addUnion(union)
+		}
+	}
+}
+
+// forget removes the types that differ from the caches, so the next view parses them again.
+func (g *Goty) forget(differ map[reflect.Type]bool) {
+	for typ := range differ {
+		delete(g.structTypes, typ)
+	}
+
+	for goName, def := range g.generics {
+		if !differ[def.Type] {
+			continue
+		}
+
+		delete(g.generics, goName)
+
+		for elem := range g.instances {
+			if instanceOf(elem) == goName {
+				delete(g.instances, elem)
+			}
+		}
+	}
+}
+
+// restore replaces the types that differ with the ones this view parsed before.
+func (g *Goty) restore(cache *viewCache, differ map[reflect.Type]bool) {
+	g.forget(differ)
+	maps.Copy(g.structTypes, cache.structTypes)
+	maps.Copy(g.generics, cache.generics)
+	maps.Copy(g.instances, cache.instances)
+}
+
+// save copies the types that differ into the view's cache, for the next Parse.
+func (g *Goty) save(cache *viewCache, differ map[reflect.Type]bool) {
+	*cache = viewCache{
+		structTypes: make(map[reflect.Type]*DataStruct),
+		generics:    make(map[string]*DataStruct),
+		instances:   make(map[reflect.Type]bool),
+	}
+
+	for typ := range differ {
+		if data, ok := g.structTypes[typ]; ok {
+			cache.structTypes[typ] = data
+		}
+	}
+
+	for goName, def := range g.generics {
+		if !differ[def.Type] {
+			continue
+		}
+
+		cache.generics[goName] = def
+
+		for elem := range g.instances {
+			if instanceOf(elem) == goName {
+				cache.instances[elem] = true
+			}
+		}
+	}
+}
+
+// instanceOf returns the go name of the generic type an instance was made from.
+func instanceOf(elem reflect.Type) string {
+	base, _, _ := splitGeneric(elem.Name())
+	return elem.PkgPath() + "." + base
+}
+
+// viewName returns the name for a type in the current view.
+// The name has the view's prefix and suffix if the type is different in this view.
+func (g *Goty) viewName(elem reflect.Type, goName string) string {
+	if g.view == nil || !g.differ[elem] {
+		return goName
+	}
+
+	return g.view.Prefix + goName + g.view.Suffix
+}
+
+// inGroups returns true if a field with these groups is included in the current view.
+func (g *Goty) inGroups(groups []string) bool {
+	if g.view == nil || g.view.Groups == nil || len(groups) == 0 {
+		return true
+	}
+
+	for _, group := range groups {
+		if slices.Contains(g.view.Groups, group) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,353 @@
+package goty_test
+
+import "golift.io/goty"
+
+type TestViews struct {
+	Name    string        `json:"name"    toml:"name"`
+	Server  TestViewsHost `json:"server"  toml:"server"`
+	Address TestAddress   `json:"address" toml:"address"`
+}
+
+type TestViewsPort struct {
+	Port int `json:"port" toml:"port"`
+}
+
+type (
+	TestViewsEvent interface{ isEvent() }
+	TestViewsLogin struct {
+		User string `json:"user" toml:"username"`
+	}
+	TestViewsEvents struct {
+		Last TestViewsEvent `json:"last" toml:"last"`
+	}
+)
+
+func (TestViewsLogin) isEvent() {}
+func (TestViewsPort) isEvent()  {}
+
+type TestViewsHost struct {
+	Host string        `json:"host" toml:"hostname"`
+	Port TestViewsPort `json:"port" toml:"port"`
+}
+
+func ExampleView() {
+	goty := goty.NewGoty(&goty.Config{Views: []goty.View{
+		{Suffix: "JSON", Profile: goty.ProfileJSON},
+		{Suffix: "TOML", Profile: goty.ProfileTOML},
+	}})
+	goty.Parse(TestViews{})
+	goty.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViews>
+	//  */
+	// export interface TestViewsJSON {
+	//   name: string;
+	//   server: TestViewsHostJSON;
+	//   address: TestAddressJSON;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsHost>
+	//  */
+	// export interface TestViewsHostJSON {
+	//   host: string;
+	//   port: TestViewsPort;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsPort>
+	//  */
+	// export interface TestViewsPort {
+	//   port: number;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestAddress>
+	//  */
+	// export interface TestAddressJSON {
+	//   city: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViews>
+	//  */
+	// export interface TestViewsTOML {
+	//   name: string;
+	//   server: TestViewsHostTOML;
+	//   address: TestAddressTOML;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsHost>
+	//  */
+	// export interface TestViewsHostTOML {
+	//   hostname: string;
+	//   port: TestViewsPort;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestAddress>
+	//  */
+	// export interface TestAddressTOML {
+	//   City: string;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+type TestGroups struct {
+	Name   string         `json:"name"`
+	Secret string         `json:"secret" goty:"groups=admin,internal"`
+	Debug  bool           `json:"debug"  goty:"groups=internal"`
+	Server TestGroupsHost `json:"server"`
+	Port   TestViewsPort  `json:"port"`
+}
+
+type TestGroupsHost struct {
+	Host  string `json:"host"`
+	Token string `json:"token" goty:"groups=admin"`
+}
+
+func ExampleView_groups() {
+	goty := goty.NewGoty(&goty.Config{Views: []goty.View{
+		{Prefix: "Public", Groups: []string{}},
+		{Prefix: "Admin", Groups: []string{"admin"}},
+	}})
+	goty.Parse(TestGroups{})
+	goty.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestGroups>
+	//  */
+	// export interface PublicTestGroups {
+	//   name: string;
+	//   server: PublicTestGroupsHost;
+	//   port: TestViewsPort;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestGroupsHost>
+	//  */
+	// export interface PublicTestGroupsHost {
+	//   host: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsPort>
+	//  */
+	// export interface TestViewsPort {
+	//   port: number;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestGroups>
+	//  */
+	// export interface AdminTestGroups {
+	//   name: string;
+	//   secret: string;
+	//   server: AdminTestGroupsHost;
+	//   port: TestViewsPort;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestGroupsHost>
+	//  */
+	// export interface AdminTestGroupsHost {
+	//   host: string;
+	//   token: string;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+type TestViewsBackup struct {
+	Server TestViewsHost `json:"server" toml:"server"`
+}
+
+func ExampleView_parse() {
+	goty := goty.NewGoty(&goty.Config{Views: []goty.View{
+		{Suffix: "JSON", Profile: goty.ProfileJSON},
+		{Suffix: "TOML", Profile: goty.ProfileTOML},
+	}})
+	goty.Parse(TestViewsHost{})
+	goty.Parse(TestViewsBackup{})
+	goty.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsHost>
+	//  */
+	// export interface TestViewsHostJSON {
+	//   host: string;
+	//   port: TestViewsPort;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsPort>
+	//  */
+	// export interface TestViewsPort {
+	//   port: number;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsHost>
+	//  */
+	// export interface TestViewsHostTOML {
+	//   hostname: string;
+	//   port: TestViewsPort;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsBackup>
+	//  */
+	// export interface TestViewsBackupJSON {
+	//   server: TestViewsHostJSON;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsBackup>
+	//  */
+	// export interface TestViewsBackupTOML {
+	//   server: TestViewsHostTOML;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+type TestViewsHoist struct {
+	Name string `json:"name" toml:"name"`
+	Auth struct {
+		User string `json:"user" toml:"username"`
+	} `json:"auth" toml:"auth"`
+}
+
+// Hoisted interfaces get the view's affix once, like any other interface.
+func ExampleView_hoist() {
+	goty := goty.NewGoty(&goty.Config{
+		GlobalOverrides: goty.Override{Hoist: true},
+		Views: []goty.View{
+			{Suffix: "JSON", Profile: goty.ProfileJSON},
+			{Suffix: "TOML", Profile: goty.ProfileTOML},
+		},
+	})
+	goty.Parse(TestViewsHoist{})
+	goty.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsHoist>
+	//  */
+	// export interface TestViewsHoistJSON {
+	//   name: string;
+	//   auth: TestViewsHoistAuthJSON;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsHoist.Auth>
+	//  */
+	// export interface TestViewsHoistAuthJSON {
+	//   user: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsHoist>
+	//  */
+	// export interface TestViewsHoistTOML {
+	//   name: string;
+	//   auth: TestViewsHoistAuthTOML;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsHoist.Auth>
+	//  */
+	// export interface TestViewsHoistAuthTOML {
+	//   username: string;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
+
+// Union implementations are parsed in each view, so the union is different in each view too.
+func ExampleView_union() {
+	builder := goty.NewGoty(&goty.Config{Views: []goty.View{
+		{Suffix: "JSON", Profile: goty.ProfileJSON},
+		{Suffix: "TOML", Profile: goty.ProfileTOML},
+	}})
+	builder.Union((*TestViewsEvent)(nil), "",
+		goty.Implementation{Type: TestViewsLogin{}},
+		goty.Implementation{Type: TestViewsPort{}},
+	)
+	builder.Parse(TestViewsEvents{})
+	builder.Print()
+	// Output:
+	// /* Auto-generated. DO NOT EDIT. Generator: https://golift.io/goty
+	//  * Edit the source code and run goty again to make updates.
+	//  */
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsEvent>
+	//  */
+	// export type TestViewsEventJSON = TestViewsLoginJSON | TestViewsPort;
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsLogin>
+	//  */
+	// export interface TestViewsLoginJSON {
+	//   user: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsPort>
+	//  */
+	// export interface TestViewsPort {
+	//   port: number;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsEvent>
+	//  */
+	// export type TestViewsEventTOML = TestViewsLoginTOML | TestViewsPort;
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsLogin>
+	//  */
+	// export interface TestViewsLoginTOML {
+	//   username: string;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsEvents>
+	//  */
+	// export interface TestViewsEventsJSON {
+	//   last: TestViewsEventJSON | null;
+	// };
+	//
+	// /**
+	//  * @see golang: <golift.io/goty_test.TestViewsEvents>
+	//  */
+	// export interface TestViewsEventsTOML {
+	//   last?: TestViewsEventTOML;
+	// };
+	//
+	// // Packages parsed:
+	// //   1. golift.io/goty_test
+}
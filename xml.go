@@ -0,0 +1,107 @@
+package goty
+
+import "strings"
+
+// encoding/xml has more than names in its tags. Attributes and character data are members
+// like any other, and nested paths like `xml:"a>b>c"` become nested objects: `a: { b: { c: T } }`.
+// This matches what most XML-to-JSON bridges produce.
+
+const (
+	// DefaultXMLCharData is the member name for `xml:",chardata"` fields.
+	DefaultXMLCharData = "#text"
+	// xmlComment is the member name for `xml:",comment"` fields.
+	xmlComment = "#comment"
+)
+
+// xmlField updates a struct field with the encoding/xml tag rules.
+// Returns false if the field is not encoded, like XMLName.
+func (g *Goty) xmlField(output *structField) bool {
+	if output.field.Name == "XMLName" {
+		return false // The element name is not a member.
+	}
+
+	// Names may have a namespace, ie. `xml:"http://example.com/ns name"`.
+	if idx := strings.LastIndexByte(output.name, ' '); idx >= 0 {
+		output.name = output.name[idx+1:]
+	}
+
+	switch {
+	case output.hasOpt("chardata"):
+		output.name, output.tagged = g.config.XMLCharData, true
+	case output.hasOpt("comment"):
+		output.name, output.tagged = xmlComment, true
+	case output.hasOpt("innerxml"):
+		output.name, output.tagged = output.field.Name, true
+	case output.hasOpt("any"):
+		output.name, output.tagged, output.fallback = "", false, true
+	}
+
+	return true
+}
+
+// nestMembers turns members with nested xml paths into nested objects.
+// Members that share a path share the objects. An object is optional if all its members are.
+func nestMembers(members []*StructMember) []*StructMember {
+	output := make([]*StructMember, 0, len(members))
+
+	for _, member := range members {
+		path := strings.FieldsFunc(member.Name, func(r rune) bool { return r == '>' })
+		if len(path) < 2 || member.IndexSignature { //nolint:mnd // A path has a parent and a child.
+			output = append(output, member)
+			continue
+		}
+
+		member.Name = path[len(path)-1]
+		list := &output
+
+		for _, name := range path[:len(path)-1] {
+			list = &nestedMember(list, name, member.parent).Members
+		}
+
+		*list = append(*list, member)
+	}
+
+	for _, member := range output {
+		member.optionalChildren()
+	}
+
+	return output
+}
+
+// nestedMember returns the nested object member with the name, and adds it to the list if it's missing.
+func nestedMember(list *[]*StructMember, name string, parent *DataStruct) *StructMember {
+	for _, member := range *list {
+		if member.Name == name && member.Member.Type == nil && member.Members != nil {
+			return member
+		}
+	}
+
+	member := &StructMember{
+		Name:    name,
+		Members: make([]*StructMember, 0),
+		doc:     parent.doc,
+		parent:  parent,
+		ovr:     &Override{},
+	}
+	*list = append(*list, member)
+
+	return member
+}
+
+// optionalChildren marks a nested xml object optional if every member in it is.
+// encoding/xml does not write the parent elements when it has no children to write.
+func (m *StructMember) optionalChildren() bool {
+	if m.Type != "" || m.Members == nil || m.Member.Type != nil {
+		return m.Optional
+	}
+
+	m.Optional = true
+
+	for _, child := range m.Members {
+		if !child.optionalChildren() {
+			m.Optional = false
+		}
+	}
+
+	return m.Optional
+}